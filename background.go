@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrBackgroundPanic is returned by Background when the function panics.
+var ErrBackgroundPanic = errors.New("background function panicked")
+
+// backgroundConfig holds the settings of a Background call.
+type backgroundConfig struct {
+	newRoot bool
+	opts    []trace.SpanStartOption
+}
+
+// BackgroundOption customizes the span started by Background.
+type BackgroundOption func(*backgroundConfig)
+
+// WithNewRootSpan makes the background span start a new trace linked to the
+// caller's span, instead of being its child.
+//
+// The new trace is sampled as a request without parent, so with the usual
+// parentBased "honor" and noParent "never" settings it is never sampled, even
+// when the caller's trace is.
+func WithNewRootSpan() BackgroundOption {
+	return func(c *backgroundConfig) {
+		c.newRoot = true
+	}
+}
+
+// WithBackgroundSpanOptions adds options used when starting the background span.
+func WithBackgroundSpanOptions(opts ...trace.SpanStartOption) BackgroundOption {
+	return func(c *backgroundConfig) {
+		c.opts = append(c.opts, opts...)
+	}
+}
+
+// Background runs fn in a new goroutine under its own span named name. The
+// context passed to fn keeps the values of ctx, including trace information
+// and baggage, but is not cancelled when ctx is, so the work may outlive the
+// request that started it.
+//
+// By default the span is a child of the caller's span, and so follows its
+// sampling decision. Use WithNewRootSpan to start a new trace linked to the
+// caller's span instead.
+//
+// The span ends when fn returns. An error returned by fn is recorded on the
+// span and a panic in fn is recovered, recorded with its stack trace and
+// turned into an error wrapping ErrBackgroundPanic. The returned channel
+// receives fn's outcome once the span has ended and is then closed.
+func (t Tracing) Background(ctx context.Context, name string, fn func(context.Context) error, opts ...BackgroundOption) <-chan error {
+	var config backgroundConfig
+	for _, o := range opts {
+		o(&config)
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	startOpts := config.opts
+	if config.newRoot {
+		if caller := trace.SpanContextFromContext(ctx); caller.IsValid() {
+			startOpts = append(startOpts, trace.WithLinks(trace.Link{SpanContext: caller}))
+		}
+		startOpts = append(startOpts, trace.WithNewRoot())
+	}
+	ctx, span := t.TracerProvider().Tracer(instrumentationName).Start(ctx, name, startOpts...)
+
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		err := runRecovered(ctx, fn, span)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		done <- err
+	}()
+	return done
+}
+
+// runRecovered calls fn, recording a panic on span and returning it as an error.
+func runRecovered(ctx context.Context, fn func(context.Context) error, span trace.Span) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrBackgroundPanic, r)
+			recordPanic(span, r, debug.Stack())
+		}
+	}()
+
+	if err = fn(ctx); err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+// recordPanic adds an exception event describing the recovered value r to span.
+func recordPanic(span trace.Span, r any, stack []byte) {
+	span.AddEvent(semconv.ExceptionEventName, trace.WithAttributes(
+		semconv.ExceptionTypeKey.String(fmt.Sprintf("%T", r)),
+		semconv.ExceptionMessageKey.String(fmt.Sprint(r)),
+		semconv.ExceptionStacktraceKey.String(string(stack)),
+		semconv.ExceptionEscapedKey.Bool(true),
+	))
+}
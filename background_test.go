@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type testContextKey struct{}
+
+func TestBackground(t *testing.T) {
+	errWork := errors.New("work failed")
+
+	tcs := []struct {
+		Description string
+		Options     []BackgroundOption
+		Fn          func(context.Context) error
+		Err         error
+		Parented    bool
+		Panicked    bool
+	}{
+		{
+			Description: "Parented by default",
+			Fn:          func(context.Context) error { return nil },
+			Parented:    true,
+		},
+		{
+			Description: "New root",
+			Options:     []BackgroundOption{WithNewRootSpan()},
+			Fn:          func(context.Context) error { return nil },
+		},
+		{
+			Description: "Error recorded",
+			Fn:          func(context.Context) error { return errWork },
+			Err:         errWork,
+			Parented:    true,
+		},
+		{
+			Description: "Panic recovered",
+			Fn:          func(context.Context) error { panic("boom") },
+			Err:         ErrBackgroundPanic,
+			Parented:    true,
+			Panicked:    true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			exporter := tracetest.NewInMemoryExporter()
+			tracing := newTestTracing(exporter)
+
+			ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testContextKey{}, "value"))
+			ctx, request := tracing.TracerProvider().Tracer("test").Start(ctx, "request")
+			request.End()
+			cancel()
+
+			var bgCtx context.Context
+			done := tracing.Background(ctx, "background", func(ctx context.Context) error {
+				bgCtx = ctx
+				return tc.Fn(ctx)
+			}, tc.Options...)
+			err := <-done
+			assert.ErrorIs(err, tc.Err)
+			_, open := <-done
+			assert.False(open)
+
+			require.NotNil(bgCtx)
+			assert.NoError(bgCtx.Err())
+			assert.Equal("value", bgCtx.Value(testContextKey{}))
+
+			spans := exporter.GetSpans()
+			require.Len(spans, 2)
+			bg := spans[1]
+			assert.Equal("background", bg.Name)
+			if tc.Parented {
+				assert.Equal(request.SpanContext().SpanID(), bg.Parent.SpanID())
+				assert.Equal(request.SpanContext().TraceID(), bg.SpanContext.TraceID())
+				assert.Empty(bg.Links)
+			} else {
+				assert.False(bg.Parent.IsValid())
+				assert.NotEqual(request.SpanContext().TraceID(), bg.SpanContext.TraceID())
+				require.Len(bg.Links, 1)
+				assert.Equal(request.SpanContext().SpanID(), bg.Links[0].SpanContext.SpanID())
+			}
+
+			if tc.Err == nil {
+				assert.Equal(codes.Unset, bg.Status.Code)
+				assert.Empty(bg.Events)
+				return
+			}
+			assert.Equal(codes.Error, bg.Status.Code)
+			require.Len(bg.Events, 1)
+			assert.Equal(semconv.ExceptionEventName, bg.Events[0].Name)
+			if tc.Panicked {
+				assert.Contains(bg.Events[0].Attributes, semconv.ExceptionMessageKey.String("boom"))
+				var stack attribute.Value
+				for _, kv := range bg.Events[0].Attributes {
+					if kv.Key == semconv.ExceptionStacktraceKey {
+						stack = kv.Value
+					}
+				}
+				assert.Contains(stack.AsString(), "runRecovered")
+			}
+		})
+	}
+}
+
+func TestBackgroundWithoutCallerSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracing := newTestTracing(exporter)
+
+	done := tracing.Background(context.Background(), "orphan", func(context.Context) error { return nil },
+		WithBackgroundSpanOptions(trace.WithAttributes(attribute.String("job", "cleanup"))))
+	require.NoError(t, <-done)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Empty(t, spans[0].Links)
+	assert.Contains(t, spans[0].Attributes, attribute.String("job", "cleanup"))
+}
+
+func TestBackgroundSampling(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Options     []BackgroundOption
+		Sampled     bool
+	}{
+		{
+			Description: "Parented follows the caller",
+			Sampled:     true,
+		},
+		{
+			Description: "New root has no parent to honor",
+			Options:     []BackgroundOption{WithNewRootSpan()},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			tracing := Tracing{tracerProvider: sdktrace.NewTracerProvider(
+				sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.NeverSample())),
+				sdktrace.WithSpanProcessor(recorder),
+			)}
+
+			ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    trace.TraceID{0x01},
+				SpanID:     trace.SpanID{0x02},
+				TraceFlags: trace.FlagsSampled,
+			}))
+			done := tracing.Background(ctx, "background", func(context.Context) error { return nil }, tc.Options...)
+			require.NoError(t, <-done)
+
+			assert.Equal(t, tc.Sampled, len(recorder.Ended()) == 1)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Well-known baggage members carried between Xmidt services.
+const (
+	PartnerIDBaggageKey = "partner-id"
+	DeviceIDBaggageKey  = "device-id"
+)
+
+// setBaggageMember returns a copy of ctx whose baggage has the member key set
+// to value.
+func setBaggageMember(ctx context.Context, key, value string) (context.Context, error) {
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid %s baggage member: %w", key, err)
+	}
+	b, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid %s baggage member: %w", key, err)
+	}
+	return baggage.ContextWithBaggage(ctx, b), nil
+}
+
+// WithPartnerID returns a copy of ctx whose baggage carries the partner ID
+// to the services called with it.
+func (t Tracing) WithPartnerID(ctx context.Context, partnerID string) (context.Context, error) {
+	return setBaggageMember(ctx, PartnerIDBaggageKey, partnerID)
+}
+
+// PartnerID returns the partner ID found in the baggage of ctx, if any.
+func (t Tracing) PartnerID(ctx context.Context) string {
+	return baggage.FromContext(ctx).Member(PartnerIDBaggageKey).Value()
+}
+
+// WithDeviceID returns a copy of ctx whose baggage carries the device ID
+// to the services called with it.
+func (t Tracing) WithDeviceID(ctx context.Context, deviceID string) (context.Context, error) {
+	return setBaggageMember(ctx, DeviceIDBaggageKey, deviceID)
+}
+
+// DeviceID returns the device ID found in the baggage of ctx, if any.
+func (t Tracing) DeviceID(ctx context.Context) string {
+	return baggage.FromContext(ctx).Member(DeviceIDBaggageKey).Value()
+}
+
+// baggageSpanProcessor copies baggage members onto spans.
+type baggageSpanProcessor struct {
+	keys []string
+}
+
+// NewBaggageSpanProcessor returns a span processor that adds the baggage
+// members named by keys, when present in the parent context, to every span as
+// attributes of the same name. Only allowlisted members are copied since
+// baggage comes from callers and may hold anything.
+func NewBaggageSpanProcessor(keys ...string) sdktrace.SpanProcessor {
+	return &baggageSpanProcessor{keys: keys}
+}
+
+func (p *baggageSpanProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	b := baggage.FromContext(ctx)
+	if b.Len() == 0 {
+		return
+	}
+	for _, key := range p.keys {
+		if m := b.Member(key); m.Key() != "" {
+			s.SetAttributes(attribute.String(key, m.Value()))
+		}
+	}
+}
+
+func (p *baggageSpanProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+func (p *baggageSpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *baggageSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
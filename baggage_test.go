@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestBaggageHelpers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var tracing Tracing
+	ctx := context.Background()
+	assert.Empty(tracing.PartnerID(ctx))
+	assert.Empty(tracing.DeviceID(ctx))
+
+	ctx, err := tracing.WithPartnerID(ctx, "comcast")
+	require.NoError(err)
+	ctx, err = tracing.WithDeviceID(ctx, "mac:112233445566")
+	require.NoError(err)
+	assert.Equal("comcast", tracing.PartnerID(ctx))
+	assert.Equal("mac:112233445566", tracing.DeviceID(ctx))
+
+	// The members survive a hop through the default propagator.
+	headers := http.Header{}
+	tracing.Propagator().Inject(ctx, propagation.HeaderCarrier(headers))
+	assert.NotEmpty(headers.Get("baggage"))
+	received := tracing.Propagator().Extract(context.Background(), propagation.HeaderCarrier(headers))
+	assert.Equal("comcast", tracing.PartnerID(received))
+	assert.Equal("mac:112233445566", tracing.DeviceID(received))
+}
+
+func TestBaggageSpanProcessor(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewBaggageSpanProcessor(PartnerIDBaggageKey, DeviceIDBaggageKey)),
+		sdktrace.WithSyncer(exporter),
+	)
+
+	var tracing Tracing
+	ctx, err := tracing.WithPartnerID(context.Background(), "comcast")
+	require.NoError(err)
+	ctx, err = setBaggageMember(ctx, "secret", "do-not-copy")
+	require.NoError(err)
+
+	_, span := tp.Tracer("test").Start(ctx, "with baggage")
+	span.End()
+	_, span = tp.Tracer("test").Start(context.Background(), "without baggage")
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(spans, 2)
+	assert.Equal([]attribute.KeyValue{attribute.String(PartnerIDBaggageKey, "comcast")}, spans[0].Attributes)
+	assert.Empty(spans[1].Attributes)
+}
+
+func TestNewBaggageAttributes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tracing, err := New(Config{
+		Provider:          "stdout",
+		SkipTraceExport:   true,
+		BaggageAttributes: []string{DeviceIDBaggageKey},
+	})
+	require.NoError(err)
+
+	exporter := tracetest.NewInMemoryExporter()
+	sdkProvider, ok := tracing.TracerProvider().(*sdktrace.TracerProvider)
+	require.True(ok)
+	sdkProvider.RegisterSpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter))
+
+	ctx, err := tracing.WithDeviceID(context.Background(), "mac:112233445566")
+	require.NoError(err)
+	_, span := tracing.TracerProvider().Tracer("test").Start(ctx, "span")
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(spans, 1)
+	assert.Contains(spans[0].Attributes, attribute.String(DeviceIDBaggageKey, "mac:112233445566"))
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xmidt-org/candlelight"
+	"gopkg.in/yaml.v3"
+)
+
+var errUnknownFormat = errors.New("unknown config format")
+
+// readConfig reads the config file at path, or stdin if path is "-". The
+// format is taken from the file extension unless one is given.
+func readConfig(path, format string, stdin io.Reader) (candlelight.Config, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path) // nolint:gosec
+	}
+	if err != nil {
+		return candlelight.Config{}, err
+	}
+
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	return decodeConfig(data, format)
+}
+
+// decodeConfig decodes a JSON or YAML config. YAML is converted to JSON first
+// so that both formats use the json tags of candlelight.Config. Durations may
+// be written as strings such as "5s" as well as numbers of nanoseconds.
+// Unknown fields are reported as errors to catch misspelled keys.
+func decodeConfig(data []byte, format string) (candlelight.Config, error) {
+	var doc any
+	switch format {
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return candlelight.Config{}, err
+		}
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return candlelight.Config{}, err
+		}
+	default:
+		return candlelight.Config{}, fmt.Errorf("%w %q, use json or yaml", errUnknownFormat, format)
+	}
+
+	if err := parseDurations(doc, reflect.TypeOf(candlelight.Config{}), ""); err != nil {
+		return candlelight.Config{}, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return candlelight.Config{}, err
+	}
+
+	var config candlelight.Config
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return candlelight.Config{}, err
+	}
+	return config, nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parseDurations replaces, in the decoded document doc, the strings held by
+// the time.Duration fields of t with their number of nanoseconds. path is
+// the location of doc, used in errors.
+func parseDurations(doc any, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			for k, v := range m {
+				// Keys are matched as encoding/json does.
+				if !strings.EqualFold(k, name) {
+					continue
+				}
+				if f.Type != durationType {
+					if err := parseDurations(v, f.Type, path+k+"."); err != nil {
+						return err
+					}
+					continue
+				}
+				if str, ok := v.(string); ok {
+					d, err := time.ParseDuration(str)
+					if err != nil {
+						return fmt.Errorf("%s%s: %w", path, k, err)
+					}
+					m[k] = int64(d)
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		l, ok := doc.([]any)
+		if !ok {
+			return nil
+		}
+		for i, v := range l {
+			if err := parseDurations(v, t.Elem(), fmt.Sprintf("%s[%d].", strings.TrimSuffix(path, "."), i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for k, v := range m {
+			if err := parseDurations(v, t.Elem(), path+k+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
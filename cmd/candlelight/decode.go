@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/xmidt-org/candlelight"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+)
+
+// decode prints the trace context carried by traceparent and tracestate
+// values, X-Xmidt-Trace-ID/X-Xmidt-Span-ID headers, or WRP trace header
+// entries.
+func decode(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, `usage: candlelight decode [ENTRY...]
+
+Each ENTRY is a "key: value" or "key=value" trace header entry, as found in
+WRP message headers, or a bare traceparent value. Entries are read one per
+line from stdin when none are given.`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	entries := fs.Args()
+	if len(entries) == 0 {
+		var err error
+		if entries, err = readEntries(stdin); err != nil {
+			fmt.Fprintf(stderr, "candlelight: reading stdin: %v\n", err)
+			return 1
+		}
+	}
+	if len(entries) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	headers, ok := parseEntries(stdout, entries)
+	if !printHeaders(stdout, headers) {
+		ok = false
+	}
+	if !ok {
+		return 1
+	}
+	return 0
+}
+
+// readEntries reads the non-blank lines of r.
+func readEntries(r io.Reader) ([]string, error) {
+	var entries []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// parseEntries collects trace header entries into headers, printing the
+// entries that cannot be parsed and reporting whether all could.
+func parseEntries(w io.Writer, entries []string) (http.Header, bool) {
+	ok := true
+	headers := http.Header{}
+	for _, entry := range entries {
+		key, value, err := candlelight.ParseTraceHeader(entry)
+		if err != nil {
+			if !isTraceParent(entry) {
+				fmt.Fprintf(w, "%v\n", err)
+				ok = false
+				continue
+			}
+			key, value = traceparentHeader, strings.TrimSpace(entry)
+		}
+		headers.Add(key, value)
+	}
+	return headers, ok
+}
+
+// printHeaders prints the trace context carried by headers, W3C headers
+// first, reporting whether all of it is valid. headers is emptied.
+func printHeaders(w io.Writer, headers http.Header) bool {
+	ok := true
+	for _, key := range []string{traceparentHeader, tracestateHeader} {
+		for _, value := range headers.Values(key) {
+			if !printW3C(w, key, value) {
+				ok = false
+			}
+		}
+		headers.Del(key)
+	}
+	if xmidt := (candlelight.XmidtPropagator{}).Fields(); headers.Get(xmidt[0]) != "" || headers.Get(xmidt[1]) != "" {
+		if !printXmidt(w, headers) {
+			ok = false
+		}
+		for _, key := range xmidt {
+			headers.Del(key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(headers)) {
+		for _, value := range headers[key] {
+			fmt.Fprintf(w, "%s: %s\n  not a trace header\n", strings.ToLower(key), value)
+		}
+	}
+	return ok
+}
+
+// isTraceParent reports whether s has the shape of a traceparent value, four
+// or more fields separated by dashes.
+func isTraceParent(s string) bool {
+	return strings.Count(s, "-") >= 3 && !strings.ContainsAny(s, " :=")
+}
+
+// printW3C prints a traceparent or tracestate value, reporting whether it is
+// valid.
+func printW3C(w io.Writer, key, value string) bool {
+	fmt.Fprintf(w, "%s: %s\n", key, value)
+	if key == tracestateHeader {
+		ts, err := trace.ParseTraceState(value)
+		ts.Walk(func(member, value string) bool {
+			fmt.Fprintf(w, "  %s: %s\n", member, value)
+			return true
+		})
+		return printValidity(w, err)
+	}
+
+	// The propagator decides validity; the fields are split here only to show
+	// what was sent, including the version the propagator does not expose.
+	fields := strings.Split(value, "-")
+	labels := []string{"version", "trace id", "span id", "flags"}
+	for i, label := range labels {
+		if i < len(fields) {
+			fmt.Fprintf(w, "  %-9s %s\n", label+":", fields[i])
+		}
+	}
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{key: value})
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		fmt.Fprintf(w, "  %-9s %t\n", "sampled:", sc.IsSampled())
+		return printValidity(w, nil)
+	}
+	return printValidity(w, errors.New("rejected by the W3C trace context propagator"))
+}
+
+// printXmidt prints the span context read from the X-Xmidt-Trace-ID and
+// X-Xmidt-Span-ID headers, reporting whether it is valid.
+func printXmidt(w io.Writer, headers http.Header) bool {
+	p := candlelight.XmidtPropagator{}
+	for _, key := range p.Fields() {
+		fmt.Fprintf(w, "%s: %s\n", strings.ToLower(key), headers.Get(key))
+	}
+	sc := trace.SpanContextFromContext(p.Extract(context.Background(), propagation.HeaderCarrier(headers)))
+	if !sc.IsValid() {
+		return printValidity(w, errors.New("both headers must hold non-zero hex IDs"))
+	}
+	fmt.Fprintf(w, "  %-9s %s\n", "trace id:", sc.TraceID())
+	fmt.Fprintf(w, "  %-9s %s\n", "span id:", sc.SpanID())
+	return printValidity(w, nil)
+}
+
+func printValidity(w io.Writer, err error) bool {
+	if err != nil {
+		fmt.Fprintf(w, "  %-9s false (%v)\n", "valid:", err)
+		return false
+	}
+	fmt.Fprintf(w, "  %-9s true\n", "valid:")
+	return true
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID      = "00f067aa0ba902b7"
+	testTraceParent = "00-" + testTraceID + "-" + testSpanID + "-01"
+)
+
+func TestDecode(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Args        []string
+		Stdin       string
+		Code        int
+		Expected    []string
+	}{
+		{
+			Description: "Bare traceparent",
+			Args:        []string{testTraceParent},
+			Expected: []string{
+				"traceparent: " + testTraceParent,
+				"version:  00",
+				"trace id: " + testTraceID,
+				"span id:  " + testSpanID,
+				"flags:    01",
+				"sampled:  true",
+				"valid:    true",
+			},
+		},
+		{
+			Description: "WRP header entries from stdin",
+			Stdin:       "traceparent: " + testTraceParent + "\n\nTraceState=congo=t61rcWkgMzE\n",
+			Expected: []string{
+				"trace id: " + testTraceID,
+				"tracestate: congo=t61rcWkgMzE",
+				"congo: t61rcWkgMzE",
+			},
+		},
+		{
+			Description: "Xmidt headers",
+			Args:        []string{"X-Xmidt-Trace-ID: " + testTraceID, "x-xmidt-span-id=" + testSpanID},
+			Expected: []string{
+				"x-xmidt-trace-id: " + testTraceID,
+				"span id:  " + testSpanID,
+				"valid:    true",
+			},
+		},
+		{
+			Description: "Unsampled",
+			Args:        []string{"00-" + testTraceID + "-" + testSpanID + "-00"},
+			Expected:    []string{"sampled:  false", "valid:    true"},
+		},
+		{
+			Description: "Invalid traceparent",
+			Args:        []string{"traceparent: ff-" + testTraceID + "-" + testSpanID + "-01"},
+			Code:        1,
+			Expected:    []string{"version:  ff", "valid:    false"},
+		},
+		{
+			Description: "Invalid tracestate",
+			Args:        []string{"tracestate: not a list"},
+			Code:        1,
+			Expected:    []string{"valid:    false"},
+		},
+		{
+			Description: "Missing Xmidt span ID",
+			Args:        []string{"X-Xmidt-Trace-ID: " + testTraceID},
+			Code:        1,
+			Expected:    []string{"x-xmidt-span-id: \n", "valid:    false"},
+		},
+		{
+			Description: "Malformed entry",
+			Args:        []string{"garbage"},
+			Code:        1,
+			Expected:    []string{"malformed trace header"},
+		},
+		{
+			Description: "Other header",
+			Args:        []string{"Content-Type: application/msgpack"},
+			Expected:    []string{"content-type: application/msgpack\n  not a trace header"},
+		},
+		{
+			Description: "Nothing to decode",
+			Code:        2,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			code, stdout, _ := runCommand(tc.Stdin, append([]string{"decode"}, tc.Args...)...)
+			assert.Equal(t, tc.Code, code)
+			for _, e := range tc.Expected {
+				assert.Contains(t, stdout, e)
+			}
+		})
+	}
+}
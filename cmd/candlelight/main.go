@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Command candlelight is a toolbox for working with candlelight tracing
+// configurations.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: candlelight <command> [arguments]
+
+commands:
+  validate   check tracing configuration files
+  send       emit test spans to the exporter of a configuration
+  decode     print the trace context carried by trace headers
+  tree       render the output of the stdout provider as trace trees
+`
+
+// command is a candlelight subcommand. It returns the process exit code.
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"validate": validate,
+	"send":     send,
+	"decode":   decode,
+	"tree":     tree,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "candlelight: unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+	return cmd(args[1:], stdin, stdout, stderr)
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runCommand runs the CLI with args and returns its exit code and output.
+func runCommand(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Args        []string
+		Code        int
+		Stdout      string
+		Stderr      string
+	}{
+		{Description: "No command", Code: 2, Stderr: "usage:"},
+		{Description: "Help", Args: []string{"help"}, Code: 0, Stdout: "usage:"},
+		{Description: "Unknown command", Args: []string{"frobnicate"}, Code: 2, Stderr: `unknown command "frobnicate"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			code, stdout, stderr := runCommand("", tc.Args...)
+			assert.Equal(t, tc.Code, code)
+			assert.Contains(t, stdout, tc.Stdout)
+			assert.Contains(t, stderr, tc.Stderr)
+		})
+	}
+}
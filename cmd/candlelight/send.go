@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/candlelight"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var errNotExporting = errors.New("the configured provider does not export spans")
+
+// attributeFlags collects repeated -attr key=value flags.
+type attributeFlags []attribute.KeyValue
+
+func (a *attributeFlags) String() string {
+	pairs := make([]string, len(*a))
+	for i, kv := range *a {
+		pairs[i] = string(kv.Key) + "=" + kv.Value.Emit()
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (a *attributeFlags) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("attribute %q must be key=value", s)
+	}
+	*a = append(*a, attribute.String(key, value))
+	return nil
+}
+
+// errorCollector is an OpenTelemetry error handler keeping the errors reported
+// by exporters and span processors.
+type errorCollector struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (c *errorCollector) Handle(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+func (c *errorCollector) err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return errors.Join(c.errs...)
+}
+
+// treeSpec describes the synthetic trace emitted by send.
+type treeSpec struct {
+	name       string
+	depth      int
+	breadth    int
+	attributes []attribute.KeyValue
+}
+
+// send emits a tree of synthetic spans through the exporter described by a
+// config file, reporting whether they could be exported.
+func send(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("send", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "", "config format, json or yaml (default: from the file extension)")
+	timeout := fs.Duration("timeout", 10*time.Second, "time allowed for exporting the spans")
+	spec := treeSpec{}
+	fs.StringVar(&spec.name, "name", "candlelight-test", "name of the root span")
+	fs.IntVar(&spec.depth, "depth", 2, "levels of child spans below the root span")
+	fs.IntVar(&spec.breadth, "breadth", 2, "children of each span")
+	fs.Var((*attributeFlags)(&spec.attributes), "attr", "key=value attribute added to every span (repeatable)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: candlelight send [flags] FILE (- for stdin)")
+		fmt.Fprintln(stderr, "Sampling settings, including tail and device sampling, are overridden so that every span is exported.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || spec.depth < 0 || spec.breadth < 0 {
+		fs.Usage()
+		return 2
+	}
+
+	config, err := readConfig(fs.Arg(0), *format, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "candlelight: %v\n", err)
+		return 1
+	}
+
+	count, traceID, err := sendTree(config, spec, *timeout)
+	if err != nil {
+		fmt.Fprintf(stderr, "candlelight: export failed: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "exported %d spans in trace %s\n", count, traceID)
+	return 0
+}
+
+// sendTree builds a tracer provider from config, emits the tree of spans and
+// flushes them, returning the number of spans and the trace ID.
+func sendTree(config candlelight.Config, spec treeSpec, timeout time.Duration) (int, string, error) {
+	config.ParentBased = "honor"
+	config.NoParent = "always"
+	// The synthetic tree matches no tail or device sampling rule, so these
+	// would drop every span.
+	config.TailSampling = candlelight.TailSamplingConfig{}
+	config.DeviceSampling = candlelight.DeviceSamplingConfig{}
+	if config.Timeout == 0 {
+		config.Timeout = timeout
+	}
+
+	collector := &errorCollector{}
+	previous := otel.GetErrorHandler()
+	otel.SetErrorHandler(collector)
+	defer otel.SetErrorHandler(previous)
+
+	tracing, err := candlelight.New(config)
+	if err != nil {
+		return 0, "", err
+	}
+	tp, ok := tracing.TracerProvider().(*sdktrace.TracerProvider)
+	if !ok {
+		return 0, "", errNotExporting
+	}
+
+	tracer := tp.Tracer("github.com/xmidt-org/candlelight/cmd/candlelight")
+	ctx, root := tracer.Start(context.Background(), spec.name, trace.WithAttributes(spec.attributes...))
+	count := 1 + emitChildren(ctx, tracer, spec, spec.name, 1)
+	root.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err = errors.Join(tp.ForceFlush(ctx), tp.Shutdown(ctx), collector.err())
+	return count, root.SpanContext().TraceID().String(), err
+}
+
+// emitChildren starts and ends the children of the span in ctx, recursively,
+// returning the number of spans emitted.
+func emitChildren(ctx context.Context, tracer trace.Tracer, spec treeSpec, parent string, level int) int {
+	if level > spec.depth {
+		return 0
+	}
+	count := 0
+	for i := 0; i < spec.breadth; i++ {
+		name := parent + "/" + strconv.Itoa(i)
+		childCtx, span := tracer.Start(ctx, name, trace.WithAttributes(spec.attributes...),
+			trace.WithAttributes(attribute.Int("candlelight.test.level", level), attribute.Int("candlelight.test.index", i)))
+		count += 1 + emitChildren(childCtx, tracer, spec, name, level+1)
+		span.End()
+	}
+	return count
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/candlelight/collectortest"
+)
+
+func startCollector(t *testing.T) *collectortest.Collector {
+	collector, err := collectortest.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = collector.Close() })
+	return collector
+}
+
+func TestSend(t *testing.T) {
+	assert := assert.New(t)
+
+	collector := startCollector(t)
+	for _, config := range []string{
+		"provider: otlp/http\nendpoint: " + collector.HTTPEndpoint + "\n",
+		"provider: otlp/grpc\nendpoint: " + collector.GRPCEndpoint + "\n",
+		"provider: otlp/http\nendpoint: " + collector.HTTPEndpoint + "\n" +
+			"tailSampling:\n  enabled: true\n  keepErrors: true\n" +
+			"deviceSampling:\n  devices: [mac:112233445566]\n",
+	} {
+		collector.Reset()
+		path := writeFile(t, "tracing.yaml", config)
+
+		code, stdout, stderr := runCommand("", "send", "-name", "probe", "-depth", "2", "-breadth", "2", "-attr", "env=test", path)
+		assert.Equal(0, code, stderr)
+		assert.Contains(stdout, "exported 7 spans in trace ")
+
+		var names []string
+		for _, s := range collector.Spans() {
+			names = append(names, s.Name)
+		}
+		assert.Len(names, 7)
+		assert.Contains(names, "probe")
+		assert.Contains(names, "probe/1/0")
+	}
+}
+
+func TestSendExportFailure(t *testing.T) {
+	collector := startCollector(t)
+	collector.Reject(true)
+	path := writeFile(t, "tracing.json", `{"provider": "otlp/http", "endpoint": "`+collector.HTTPEndpoint+`"}`)
+
+	code, _, stderr := runCommand("", "send", "-timeout", "5s", path)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "export failed")
+}
+
+func TestSendErrors(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Args        []string
+		Content     string
+		Code        int
+		Stderr      string
+	}{
+		{Description: "Missing file", Code: 2, Stderr: "usage: candlelight send"},
+		{Description: "Bad attribute", Args: []string{"-attr", "novalue"}, Content: `{}`, Code: 2, Stderr: "must be key=value"},
+		{Description: "Negative depth", Args: []string{"-depth", "-1"}, Content: `{}`, Code: 2, Stderr: "usage: candlelight send"},
+		{Description: "Invalid config", Content: `{"provider": "nope"}`, Code: 1, Stderr: "could not be found"},
+		{Description: "Noop provider", Content: `{"provider": "noop"}`, Code: 1, Stderr: errNotExporting.Error()},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			args := append([]string{"send"}, tc.Args...)
+			if tc.Content != "" {
+				args = append(args, writeFile(t, "tracing.json", tc.Content))
+			}
+			code, _, stderr := runCommand("", args...)
+			assert.Equal(t, tc.Code, code)
+			assert.Contains(t, stderr, tc.Stderr)
+		})
+	}
+}
+
+func TestSendStdout(t *testing.T) {
+	path := writeFile(t, "tracing.json", `{"provider": "stdout", "skipTraceExport": true}`)
+	code, stdout, _ := runCommand("", "send", "-depth", "0", path)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "exported 1 spans")
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xmidt-org/candlelight"
+)
+
+// tree renders the spans written by the stdout provider as one indented tree
+// per trace.
+func tree(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("tree", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: candlelight tree [FILE] (default: stdin)")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	in := stdin
+	if path := fs.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path) // nolint:gosec
+		if err != nil {
+			fmt.Fprintf(stderr, "candlelight: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	trees, err := candlelight.ReadTraceTrees(in)
+	if err != nil {
+		fmt.Fprintf(stderr, "candlelight: %v\n", err)
+		return 1
+	}
+	for _, t := range trees {
+		if err := t.Render(stdout); err != nil {
+			fmt.Fprintf(stderr, "candlelight: %v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
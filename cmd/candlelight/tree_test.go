@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRootSpan = `{"Name": "GET /devices", "SpanContext": {"TraceID": "` + testTraceID + `", "SpanID": "` + testSpanID + `"},
+	"Parent": {"TraceID": "00000000000000000000000000000000", "SpanID": "0000000000000000"},
+	"StartTime": "2026-01-02T03:04:05Z", "EndTime": "2026-01-02T03:04:05.003Z", "Status": {"Code": "Unset"}}`
+	testChildSpan = `{"Name": "lookup", "SpanContext": {"TraceID": "` + testTraceID + `", "SpanID": "1111111111111111"},
+	"Parent": {"TraceID": "` + testTraceID + `", "SpanID": "` + testSpanID + `"},
+	"StartTime": "2026-01-02T03:04:05.001Z", "EndTime": "2026-01-02T03:04:05.002Z", "Status": {"Code": "Error", "Description": "timeout"}}`
+	testTree = "trace " + testTraceID + "\n└── GET /devices (3ms) Unset\n    └── lookup (1ms) Error: timeout\n"
+
+	// testCyclicSpan is the parent of testChildSpan and its child.
+	testCyclicSpan = `{"Name": "GET /devices", "SpanContext": {"TraceID": "` + testTraceID + `", "SpanID": "` + testSpanID + `"},
+	"Parent": {"TraceID": "` + testTraceID + `", "SpanID": "1111111111111111"},
+	"StartTime": "2026-01-02T03:04:05Z", "EndTime": "2026-01-02T03:04:05.003Z", "Status": {"Code": "Unset"}}`
+	testCyclicTree = "trace " + testTraceID + "\n└── GET /devices (3ms) Unset [parent 1111111111111111 forms a cycle]\n" +
+		"    └── lookup (1ms) Error: timeout\n"
+)
+
+func TestTree(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Args        []string
+		Stdin       string
+		Code        int
+		Stdout      string
+		Stderr      string
+	}{
+		{Description: "Stdin", Stdin: testChildSpan + "\n" + testRootSpan, Stdout: testTree},
+		{Description: "Stdin dash", Args: []string{"-"}, Stdin: testRootSpan + testChildSpan, Stdout: testTree},
+		{Description: "File", Args: []string{"FILE"}, Stdout: testTree},
+		{Description: "Cyclic parents", Stdin: testCyclicSpan + testChildSpan, Stdout: testCyclicTree},
+		{Description: "Empty input"},
+		{Description: "Missing file", Args: []string{"/does/not/exist"}, Code: 1, Stderr: "no such file"},
+		{Description: "Invalid input", Stdin: "not json", Code: 1, Stderr: "invalid stdout span"},
+		{Description: "Too many files", Args: []string{"a", "b"}, Code: 2, Stderr: "usage: candlelight tree"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			args := []string{"tree"}
+			for _, a := range tc.Args {
+				if a == "FILE" {
+					a = writeFile(t, "spans.json", testRootSpan+"\n"+testChildSpan)
+				}
+				args = append(args, a)
+			}
+			code, stdout, stderr := runCommand(tc.Stdin, args...)
+			assert.Equal(t, tc.Code, code)
+			assert.Equal(t, tc.Stdout, stdout)
+			assert.Contains(t, stderr, tc.Stderr)
+		})
+	}
+}
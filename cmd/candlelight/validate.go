@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// validate checks config files with the same rules used when building a
+// tracer provider, printing every problem found.
+func validate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "", "config format, json or yaml (default: from the file extension)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: candlelight validate [-format json|yaml] FILE... (- for stdin)")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	code := 0
+	for _, path := range fs.Args() {
+		config, err := readConfig(path, *format, stdin)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", path, err)
+			code = 1
+			continue
+		}
+		problems := unwrapJoined(config.Validate())
+		if len(problems) == 0 {
+			fmt.Fprintf(stdout, "%s: ok\n", path)
+			continue
+		}
+		code = 1
+		for _, p := range problems {
+			fmt.Fprintf(stdout, "%s: %v\n", path, p)
+		}
+	}
+	return code
+}
+
+// unwrapJoined returns the errors joined by errors.Join in err, as returned
+// by candlelight.Config.Validate, or err alone otherwise.
+func unwrapJoined(err error) []error {
+	if err == nil {
+		return nil
+	}
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		return []error{err}
+	}
+	return joined.Unwrap()
+}
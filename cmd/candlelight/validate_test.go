@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/candlelight"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestValidate(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Name        string
+		Content     string
+		Args        []string
+		Code        int
+		Expected    []string
+	}{
+		{
+			Description: "Valid JSON",
+			Name:        "tracing.json",
+			Content:     `{"provider": "otlp/grpc", "endpoint": "localhost:4317", "parentBased": "honor", "noParent": "always"}`,
+			Expected:    []string{"tracing.json: ok"},
+		},
+		{
+			Description: "Valid YAML",
+			Name:        "tracing.yaml",
+			Content:     "provider: zipkin\nendpoint: http://localhost:9411/api/v2/spans\nparentBased: honor\n",
+			Expected:    []string{"tracing.yaml: ok"},
+		},
+		{
+			Description: "YAML durations",
+			Name:        "tracing.yaml",
+			Content: "provider: otlp/grpc\nendpoint: localhost:4317\ntimeout: 5s\n" +
+				"retry:\n  enabled: true\n  initialInterval: 500ms\n  maxElapsedTime: 10000000000\n" +
+				"tailSampling:\n  enabled: true\n  decisionWait: 30s\n  keepErrors: true\n" +
+				"errorLimit:\n  interval: 1m\n",
+			Expected: []string{"tracing.yaml: ok"},
+		},
+		{
+			Description: "JSON durations",
+			Name:        "tracing.json",
+			Content:     `{"provider": "otlp/http", "endpoint": "localhost:4318", "timeout": "5s", "retry": {"maxInterval": 2000000000}}`,
+			Expected:    []string{"tracing.json: ok"},
+		},
+		{
+			Description: "Invalid duration",
+			Name:        "tracing.yaml",
+			Content:     "provider: stdout\ntailSampling:\n  decisionWait: soon\n",
+			Code:        1,
+			Expected:    []string{`tailSampling.decisionWait: time: invalid duration "soon"`},
+		},
+		{
+			Description: "Every problem reported",
+			Name:        "tracing.yml",
+			Content:     "provider: jaeger\nparentBased: dishonor\nnoParent: sometimes\n",
+			Code:        1,
+			Expected: []string{
+				"invalid config field parentBased",
+				"invalid config field noParent",
+				"invalid config field endpoint: endpoint is required by the provider",
+			},
+		},
+		{
+			Description: "Unknown provider",
+			Name:        "tracing.json",
+			Content:     `{"provider": "otlp/grcp", "endpoint": "localhost:4317"}`,
+			Code:        1,
+			Expected:    []string{"tracerProvider builder could not be found for provider otlp/grcp"},
+		},
+		{
+			Description: "Misspelled field",
+			Name:        "tracing.json",
+			Content:     `{"provider": "stdout", "parentbase": "honor"}`,
+			Code:        1,
+			Expected:    []string{`unknown field "parentbase"`},
+		},
+		{
+			Description: "Unknown format",
+			Name:        "tracing.toml",
+			Content:     `provider = "stdout"`,
+			Code:        1,
+			Expected:    []string{"unknown config format"},
+		},
+		{
+			Description: "Format flag",
+			Name:        "tracing.conf",
+			Content:     "provider: stdout\n",
+			Args:        []string{"-format", "yaml"},
+			Expected:    []string{"tracing.conf: ok"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			path := writeFile(t, tc.Name, tc.Content)
+			args := append(append([]string{"validate"}, tc.Args...), path)
+			code, stdout, _ := runCommand("", args...)
+			assert.Equal(t, tc.Code, code)
+			for _, e := range tc.Expected {
+				assert.Contains(t, stdout, e)
+			}
+		})
+	}
+}
+
+func TestValidateStdin(t *testing.T) {
+	code, stdout, _ := runCommand(`{"provider": "noop"}`, "validate", "-format", "json", "-")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "-: ok\n", stdout)
+}
+
+func TestValidateUsage(t *testing.T) {
+	code, _, stderr := runCommand("", "validate")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "usage: candlelight validate")
+
+	code, stdout, _ := runCommand("", "validate", filepath.Join(t.TempDir(), "missing.json"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, "no such file")
+}
+
+func TestDecodeConfigDurations(t *testing.T) {
+	config, err := decodeConfig([]byte("timeout: 5s\nretry:\n  initialInterval: 250ms\nerrorLimit:\n  interval: 2m\n"), "yaml")
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, config.Timeout)
+	assert.Equal(t, 250*time.Millisecond, config.Retry.InitialInterval)
+	assert.Equal(t, 2*time.Minute, config.ErrorLimit.Interval)
+
+	config, err = decodeConfig([]byte(`{"timeout": 1000}`), "json")
+	require.NoError(t, err)
+	assert.Equal(t, time.Microsecond, config.Timeout)
+}
+
+func TestValidateAgreesWithConfigureTracerProvider(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Content     string
+		Valid       bool
+	}{
+		{
+			Description: "Otlp endpoint URL",
+			Content:     `{"provider": "otlp/grpc", "endpoint": "http://localhost"}`,
+			Valid:       true,
+		},
+		{
+			Description: "Otlp endpoint with path",
+			Content:     `{"provider": "otlp/http", "endpoint": "localhost:4318/v1/traces"}`,
+		},
+		{
+			Description: "Unused noParent",
+			Content:     `{"provider": "stdout", "parentBased": "ignore", "noParent": "sometimes"}`,
+			Valid:       true,
+		},
+		{
+			Description: "Negative timeout",
+			Content:     `{"provider": "otlp/grpc", "endpoint": "localhost:4317", "timeout": "-1s"}`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			config, err := decodeConfig([]byte(tc.Content), "json")
+			require.NoError(err)
+			tp, err := candlelight.ConfigureTracerProvider(config)
+			if sdkProvider, ok := tp.(*sdktrace.TracerProvider); ok {
+				_ = sdkProvider.Shutdown(context.Background())
+			}
+			assert.Equal(tc.Valid, err == nil)
+
+			code, stdout, _ := runCommand(tc.Content, "validate", "-format", "json", "-")
+			assert.Equal(tc.Valid, code == 0, stdout)
+		})
+	}
+}
@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package collectortest provides an in-process OTLP trace collector for
+// testing exporters end to end, in the spirit of net/http/httptest.
+package collectortest
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	// Registers the gzip decompressor used by exporters configured with
+	// gzip compression.
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// Protocols of the requests received by a Collector.
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+)
+
+var errRejected = errors.New("export rejected by the test collector")
+
+// Request is an export request received by a Collector.
+type Request struct {
+	// Protocol is ProtocolGRPC or ProtocolHTTP.
+	Protocol string
+
+	// Path is the URL path of HTTP requests, or the full method name of gRPC
+	// calls.
+	Path string
+
+	// Header holds the HTTP headers or the gRPC metadata of the request.
+	Header http.Header
+
+	// Compression is the content encoding of the request, such as "gzip",
+	// or empty if it was not compressed.
+	Compression string
+
+	Spans []Span
+}
+
+// Span is a span received by a Collector, along with the resource and
+// instrumentation scope it was sent with.
+type Span struct {
+	*tracepb.Span
+	Resource *resourcepb.Resource
+	Scope    *commonpb.InstrumentationScope
+}
+
+// Collector is an OTLP trace receiver listening on loopback for both gRPC
+// and HTTP. It records the spans it receives for later assertions.
+type Collector struct {
+	// GRPCEndpoint and HTTPEndpoint are the host:port addresses of the
+	// receivers, suitable for Config.Endpoint of the otlp/grpc and otlp/http
+	// providers.
+	GRPCEndpoint string
+	HTTPEndpoint string
+
+	grpcServer *grpc.Server
+	httpServer *http.Server
+
+	mu       sync.Mutex
+	requests []Request
+	reject   bool
+	changed  chan struct{}
+}
+
+// New starts a Collector. Callers should call Close when done.
+func New() (*Collector, error) {
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed listening for gRPC: %w", err)
+	}
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		_ = grpcListener.Close()
+		return nil, fmt.Errorf("failed listening for HTTP: %w", err)
+	}
+
+	c := &Collector{
+		GRPCEndpoint: grpcListener.Addr().String(),
+		HTTPEndpoint: httpListener.Addr().String(),
+		grpcServer:   grpc.NewServer(grpc.StatsHandler(compressionRecorder{})),
+		changed:      make(chan struct{}),
+	}
+	c.httpServer = &http.Server{Handler: http.HandlerFunc(c.serveHTTP)} // nolint:gosec
+	coltracepb.RegisterTraceServiceServer(c.grpcServer, grpcReceiver{c: c})
+
+	go func() { _ = c.grpcServer.Serve(grpcListener) }()
+	go func() { _ = c.httpServer.Serve(httpListener) }()
+	return c, nil
+}
+
+// Close stops both receivers.
+func (c *Collector) Close() error {
+	c.grpcServer.Stop()
+	return c.httpServer.Close()
+}
+
+// Reject makes the collector refuse the following export requests with a
+// non-retryable error when reject is true: InvalidArgument over gRPC and
+// 400 Bad Request over HTTP.
+func (c *Collector) Reject(reject bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reject = reject
+}
+
+// Requests returns the export requests received so far.
+func (c *Collector) Requests() []Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Request(nil), c.requests...)
+}
+
+// Spans returns the spans received so far, in the order they arrived.
+func (c *Collector) Spans() []Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.spans()
+}
+
+func (c *Collector) spans() []Span {
+	var spans []Span
+	for _, r := range c.requests {
+		spans = append(spans, r.Spans...)
+	}
+	return spans
+}
+
+// WaitForSpans blocks until at least n spans were received, returning them,
+// or until ctx is done.
+func (c *Collector) WaitForSpans(ctx context.Context, n int) ([]Span, error) {
+	for {
+		c.mu.Lock()
+		spans, changed := c.spans(), c.changed
+		c.mu.Unlock()
+		if len(spans) >= n {
+			return spans, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return spans, fmt.Errorf("received %d of %d spans: %w", len(spans), n, ctx.Err())
+		}
+	}
+}
+
+// Reset forgets the requests received so far.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = nil
+}
+
+// record stores an export request, unless the collector rejects them.
+func (c *Collector) record(r Request, resourceSpans []*tracepb.ResourceSpans) error {
+	for _, rs := range resourceSpans {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				r.Spans = append(r.Spans, Span{Span: s, Resource: rs.GetResource(), Scope: ss.GetScope()})
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.reject {
+		return errRejected
+	}
+	c.requests = append(c.requests, r)
+	close(c.changed)
+	c.changed = make(chan struct{})
+	return nil
+}
+
+type grpcReceiver struct {
+	coltracepb.UnimplementedTraceServiceServer
+	c *Collector
+}
+
+func (g grpcReceiver) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := make(http.Header, len(md))
+	for k, v := range md {
+		header[http.CanonicalHeaderKey(k)] = v
+	}
+	method, _ := grpc.Method(ctx)
+	r := Request{Protocol: ProtocolGRPC, Path: method, Header: header}
+	if compression, ok := ctx.Value(compressionKey{}).(*string); ok {
+		r.Compression = *compression
+	}
+	err := g.c.record(r, req.GetResourceSpans())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+type compressionKey struct{}
+
+// compressionRecorder makes the compression of gRPC calls, which is not part
+// of the metadata seen by handlers, available in their context.
+type compressionRecorder struct{}
+
+func (compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, compressionKey{}, new(string))
+}
+
+func (compressionRecorder) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InHeader); ok {
+		if compression, ok := ctx.Value(compressionKey{}).(*string); ok {
+			*compression = in.Compression
+		}
+	}
+}
+
+func (compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+// serveHTTP receives OTLP/HTTP export requests on any path, encoded as
+// protobuf or JSON and optionally gzip compressed.
+func (c *Collector) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		body = gz
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req coltracepb.ExportTraceServiceRequest
+	unmarshal, marshal, contentType := proto.Unmarshal, proto.Marshal, "application/x-protobuf"
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		unmarshal, marshal, contentType = protojson.Unmarshal, protojson.Marshal, "application/json"
+	}
+	if err := unmarshal(data, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := Request{
+		Protocol:    ProtocolHTTP,
+		Path:        r.URL.Path,
+		Header:      r.Header.Clone(),
+		Compression: r.Header.Get("Content-Encoding"),
+	}
+	err = c.record(request, req.GetResourceSpans())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := marshal(&coltracepb.ExportTraceServiceResponse{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(resp)
+}
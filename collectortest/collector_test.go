@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package collectortest
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func newCollector(t *testing.T) *Collector {
+	c, err := New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func newExporter(t *testing.T, c *Collector, protocol string) sdktrace.SpanExporter {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch protocol {
+	case ProtocolGRPC:
+		exporter, err = otlptracegrpc.New(context.Background(),
+			otlptracegrpc.WithEndpoint(c.GRPCEndpoint),
+			otlptracegrpc.WithInsecure(),
+			otlptracegrpc.WithCompressor("gzip"),
+			otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}),
+		)
+	case ProtocolHTTP:
+		exporter, err = otlptracehttp.New(context.Background(),
+			otlptracehttp.WithEndpoint(c.HTTPEndpoint),
+			otlptracehttp.WithInsecure(),
+			otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+			otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+		)
+	}
+	require.NoError(t, err)
+	return exporter
+}
+
+func TestCollector(t *testing.T) {
+	tcs := []struct {
+		Protocol string
+		Path     string
+	}{
+		{Protocol: ProtocolGRPC, Path: "/opentelemetry.proto.collector.trace.v1.TraceService/Export"},
+		{Protocol: ProtocolHTTP, Path: "/v1/traces"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Protocol, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			c := newCollector(t)
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(newExporter(t, c, tc.Protocol)))
+			defer func() { _ = tp.Shutdown(context.Background()) }()
+
+			ctx, parent := tp.Tracer("collectortest").Start(context.Background(), "parent")
+			_, child := tp.Tracer("collectortest").Start(ctx, "child")
+			child.End()
+			parent.End()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			spans, err := c.WaitForSpans(ctx, 2)
+			require.NoError(err)
+			require.Len(spans, 2)
+			assert.Equal("child", spans[0].Name)
+			assert.Equal("parent", spans[1].Name)
+			assert.Equal(spans[1].SpanId, spans[0].ParentSpanId)
+			assert.Equal("collectortest", spans[0].Scope.GetName())
+			assert.NotEmpty(spans[0].Resource.GetAttributes())
+
+			requests := c.Requests()
+			require.Len(requests, 2)
+			assert.Equal(tc.Protocol, requests[0].Protocol)
+			assert.Equal(tc.Path, requests[0].Path)
+			assert.Equal("gzip", requests[0].Compression)
+
+			c.Reset()
+			assert.Empty(c.Spans())
+		})
+	}
+}
+
+func TestCollectorReject(t *testing.T) {
+	for _, protocol := range []string{ProtocolGRPC, ProtocolHTTP} {
+		t.Run(protocol, func(t *testing.T) {
+			c := newCollector(t)
+			exporter := newExporter(t, c, protocol)
+			defer func() { _ = exporter.Shutdown(context.Background()) }()
+
+			c.Reject(true)
+			tp := sdktrace.NewTracerProvider()
+			_, span := tp.Tracer("collectortest").Start(context.Background(), "rejected")
+			span.End()
+			ro, ok := span.(sdktrace.ReadOnlySpan)
+			require.True(t, ok)
+
+			err := exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{ro})
+			assert.Error(t, err)
+			assert.Empty(t, c.Requests())
+
+			c.Reject(false)
+			assert.NoError(t, exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{ro}))
+			assert.Len(t, c.Spans(), 1)
+		})
+	}
+}
+
+func TestCollectorHTTPJSON(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newCollector(t)
+	body, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Spans: []*tracepb.Span{{Name: "json", TraceId: bytes.Repeat([]byte{1}, 16), SpanId: bytes.Repeat([]byte{2}, 8)}},
+			}},
+		}},
+	})
+	require.NoError(err)
+
+	resp, err := http.Post("http://"+c.HTTPEndpoint+"/v1/traces", "application/json", bytes.NewReader(body)) // nolint:noctx
+	require.NoError(err)
+	defer resp.Body.Close()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("application/json", resp.Header.Get("Content-Type"))
+	require.Len(c.Spans(), 1)
+	assert.Equal("json", c.Spans()[0].Name)
+
+	resp, err = http.Get("http://" + c.HTTPEndpoint + "/v1/traces") // nolint:noctx
+	require.NoError(err)
+	defer resp.Body.Close()
+	assert.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestCollectorWaitForSpansTimeout(t *testing.T) {
+	c := newCollector(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	spans, err := c.WaitForSpans(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, spans)
+}
@@ -4,9 +4,34 @@
 package candlelight
 
 import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
+var (
+	ErrMissingEndpoint    = errors.New("endpoint is required by the provider")
+	ErrInvalidEndpoint    = errors.New("invalid endpoint")
+	ErrInvalidCompression = errors.New("invalid compression value provided in configuration")
+	ErrInvalidTimeout     = errors.New("invalid timeout value provided in configuration")
+	ErrInvalidURLPath     = errors.New("invalid URL path provided in configuration")
+)
+
+// Compression values supported by the otlp providers.
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+)
+
 // Config specifies parameters relevant for otel trace provider.
 type Config struct {
 	// ApplicationName is the name for this application.
@@ -15,7 +40,10 @@ type Config struct {
 	// Provider is the name of the trace provider to use.
 	Provider string `json:"provider"`
 
-	// Endpoint is the endpoint to which spans need to be submitted.
+	// Endpoint is the endpoint to which spans need to be submitted. The otlp
+	// providers take host[:port], sent without TLS, or an http or https URL;
+	// jaeger and zipkin take an http or https URL. The built-in providers
+	// fail to build with an endpoint that Validate rejects.
 	Endpoint string `json:"endpoint"`
 
 	// SkipTraceExport works only in case of provider stdout. Set
@@ -30,6 +58,9 @@ type Config struct {
 	// ParentBased and NoParent dictate if and when new spans should be created.
 	// ParentBased = "ignore" (default), tracing is effectively turned off and the "NoParent" value is ignored
 	// ParentBased = "honor", the sampling decision is made by the parent of the span
+	// When neither ParentBased nor NoParent is set, the jaeger and zipkin
+	// providers sample every span and the stdout provider samples the spans
+	// whose parent is sampled, as well as root spans.
 	ParentBased string `json:"parentBased"`
 
 	// NoParent decides if a root span should be initiated in the case where there is no existing parent
@@ -40,6 +71,298 @@ type Config struct {
 
 	// HeaderPrefix allows the client to specify the header relevant to their application's trace information
 	HeaderPrefix string `json:"HeaderPrefix"`
+
+	// MeterProvider, if set, receives request rate, error rate and latency
+	// metrics derived from every span that ends. See NewSpanMetricsProcessor.
+	MeterProvider metric.MeterProvider `json:"-"`
+
+	// Redactions are applied in order to span, event and link attributes
+	// before the built-in providers export spans. Resource attributes are
+	// not redacted. Hash rules should set a HashKey to keep hashed values
+	// from being reversed. See RedactionRule.
+	Redactions []RedactionRule `json:"redactions"`
+
+	// SpanLimits bounds the amount of data recorded on each span by the
+	// built-in providers.
+	SpanLimits SpanLimits `json:"spanLimits"`
+
+	// Compression applies to spans sent by the otlp providers.
+	// Compression = "none" (default), spans are sent uncompressed
+	// Compression = "gzip", spans are gzip compressed
+	Compression string `json:"compression"`
+
+	// Timeout bounds each export done by the otlp providers, including
+	// retries. Zero uses the exporter default of 10 seconds.
+	Timeout time.Duration `json:"timeout"`
+
+	// URLPath is the path spans are posted to by the otlp/http provider.
+	// Empty uses the default of "/v1/traces".
+	URLPath string `json:"urlPath"`
+
+	// Retry configures retrying failed exports, and spooling them to disk,
+	// for the built-in providers. See RetryConfig.
+	Retry RetryConfig `json:"retry"`
+
+	// BaggageAttributes lists the baggage members copied onto every span as
+	// attributes, e.g. "partner-id" and "device-id". See NewBaggageSpanProcessor.
+	BaggageAttributes []string `json:"baggageAttributes"`
+
+	// TailSampling configures keeping or dropping whole traces after their
+	// spans end, for the built-in providers. See TailSamplingConfig.
+	TailSampling TailSamplingConfig `json:"tailSampling"`
+
+	// DeviceSampling selects devices whose requests are always sampled. See
+	// DeviceSamplingConfig.
+	DeviceSampling DeviceSamplingConfig `json:"deviceSampling"`
+
+	// RegisterGlobal makes New install the tracer provider and the
+	// propagator as the OpenTelemetry globals. See SetGlobal.
+	RegisterGlobal bool `json:"registerGlobal"`
+
+	// ErrorHandler, if set, receives the errors of the exporters of the
+	// built-in providers as TracingErrors, naming the provider and endpoint.
+	// New installs it as the OpenTelemetry global error handler, through
+	// which it also receives, as they are, the errors reported by the span
+	// processors and other OpenTelemetry components; the last Tracing created
+	// with an ErrorHandler receives them all. ConfigureTracerProvider does
+	// not install it, so the span processors then also pass failed exports
+	// to the global error handler. See also WithLogger.
+	ErrorHandler otel.ErrorHandler `json:"-"`
+
+	// ErrorLimit bounds the rate of the errors passed to ErrorHandler.
+	ErrorLimit ErrorLimit `json:"errorLimit"`
+
+	// options holds the settings given to New.
+	options options
+}
+
+// DefaultAttributeValueLengthLimit is the maximum length of string attribute
+// values used when neither SpanLimits nor the environment set one.
+const DefaultAttributeValueLengthLimit = 4096
+
+// SpanLimits bounds the data recorded on a span. Zero fields fall back to the
+// OTEL_SPAN_* and OTEL_ATTRIBUTE_* environment variables and then to the
+// OpenTelemetry defaults of 128 attributes, events and links, except that
+// attribute values are capped at DefaultAttributeValueLengthLimit instead of
+// being unlimited. Negative fields remove the corresponding limit.
+type SpanLimits struct {
+	// AttributeValueLengthLimit is the maximum length of string attribute values.
+	// Longer values are truncated.
+	AttributeValueLengthLimit int `json:"attributeValueLengthLimit"`
+
+	// AttributeCountLimit is the maximum number of attributes on a span.
+	AttributeCountLimit int `json:"attributeCountLimit"`
+
+	// EventCountLimit is the maximum number of events on a span. The oldest
+	// events are dropped once the limit is reached.
+	EventCountLimit int `json:"eventCountLimit"`
+
+	// LinkCountLimit is the maximum number of links on a span.
+	LinkCountLimit int `json:"linkCountLimit"`
+
+	// AttributePerEventCountLimit is the maximum number of attributes on an event.
+	AttributePerEventCountLimit int `json:"attributePerEventCountLimit"`
+
+	// AttributePerLinkCountLimit is the maximum number of attributes on a link.
+	AttributePerLinkCountLimit int `json:"attributePerLinkCountLimit"`
+}
+
+// sdkSpanLimits converts l to the limits used by the OpenTelemetry SDK,
+// filling in the defaults.
+func (l SpanLimits) sdkSpanLimits() sdktrace.SpanLimits {
+	limits := sdktrace.NewSpanLimits()
+	if limits.AttributeValueLengthLimit < 0 {
+		limits.AttributeValueLengthLimit = DefaultAttributeValueLengthLimit
+	}
+	override := func(limit *int, value int) {
+		if value != 0 {
+			*limit = value
+		}
+	}
+	override(&limits.AttributeValueLengthLimit, l.AttributeValueLengthLimit)
+	override(&limits.AttributeCountLimit, l.AttributeCountLimit)
+	override(&limits.EventCountLimit, l.EventCountLimit)
+	override(&limits.LinkCountLimit, l.LinkCountLimit)
+	override(&limits.AttributePerEventCountLimit, l.AttributePerEventCountLimit)
+	override(&limits.AttributePerLinkCountLimit, l.AttributePerLinkCountLimit)
+	return limits
+}
+
+// FieldError describes a problem with a single Config field. Field is the
+// name of the field as it appears in configuration files.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid config field %s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks every field of the configuration and returns all the
+// problems found joined together, or nil if the configuration is usable.
+// Each problem is a *FieldError wrapping one of the package's sentinel
+// errors, so errors.Is and errors.As can be used on the result.
+//
+// Endpoint syntax is only checked for the built-in providers. Providers
+// overridden through the Providers field are free to interpret the endpoint
+// however they choose.
+func (c Config) Validate() error {
+	provider := strings.ToLower(c.Provider)
+	if provider == "" {
+		provider = DefaultTracerProvider
+	}
+
+	errs := c.validateProvider(provider)
+	errs = append(errs, c.validateSampling()...)
+	errs = append(errs, c.validateExport(provider)...)
+	errs = append(errs, c.Retry.validate()...)
+	errs = append(errs, c.TailSampling.validate()...)
+	errs = append(errs, c.DeviceSampling.validate()...)
+	errs = append(errs, c.ErrorLimit.validate()...)
+	_, redactionErrs := compileRedactionRules(c.Redactions)
+	errs = append(errs, redactionErrs...)
+
+	return errors.Join(errs...)
+}
+
+// validateProvider checks that provider exists, unless the exporter given
+// with WithExporter replaces it.
+func (c Config) validateProvider(provider string) []error {
+	_, custom := c.Providers[provider]
+	_, builtIn := providersConfig[provider]
+	if custom || builtIn || c.options.exporter != nil {
+		return nil
+	}
+	return []error{&FieldError{
+		Field: "provider",
+		Err:   fmt.Errorf("%w for provider %s", ErrTracerProviderNotFound, provider),
+	}}
+}
+
+// validateSampling checks the parentBased and noParent settings.
+func (c Config) validateSampling() []error {
+	var errs []error
+	switch c.ParentBased {
+	case "", "ignore", "honor":
+	default:
+		errs = append(errs, &FieldError{Field: "parentBased", Err: ErrInvalidParentBasedValue})
+	}
+
+	// NoParent is only used when parents are honored. It is also checked
+	// when parentBased is invalid, to report every problem at once.
+	switch c.NoParent {
+	case "", "never", "always":
+	default:
+		if c.ParentBased != "" && c.ParentBased != "ignore" {
+			errs = append(errs, &FieldError{Field: "noParent", Err: ErrInvalidNoParentValue})
+		}
+	}
+	return errs
+}
+
+// validateExport checks the endpoint of the built-in provider, if provider
+// is one and no exporter replaces it, and the settings of the OTLP exporters.
+func (c Config) validateExport(provider string) []error {
+	var errs []error
+	_, custom := c.Providers[provider]
+	if check, ok := endpointValidators[provider]; ok && !custom && c.options.exporter == nil {
+		if err := check(c.Endpoint); err != nil {
+			errs = append(errs, &FieldError{Field: "endpoint", Err: err})
+		}
+	}
+
+	switch c.Compression {
+	case "", CompressionNone, CompressionGzip:
+	default:
+		errs = append(errs, &FieldError{
+			Field: "compression",
+			Err:   fmt.Errorf("%w: %q", ErrInvalidCompression, c.Compression),
+		})
+	}
+
+	if c.Timeout < 0 {
+		errs = append(errs, &FieldError{
+			Field: "timeout",
+			Err:   fmt.Errorf("%w: %s is negative", ErrInvalidTimeout, c.Timeout),
+		})
+	}
+
+	if c.URLPath != "" && !strings.HasPrefix(c.URLPath, "/") {
+		errs = append(errs, &FieldError{
+			Field: "urlPath",
+			Err:   fmt.Errorf("%w: %q must start with /", ErrInvalidURLPath, c.URLPath),
+		})
+	}
+	return errs
+}
+
+// endpointValidators holds the endpoint syntax checks for the built-in
+// providers that export spans over the network.
+var endpointValidators = map[string]func(string) error{
+	"otlp/grpc": validateOTLPEndpoint,
+	"otlp/http": validateOTLPEndpoint,
+	"jaeger":    validateURL,
+	"zipkin":    validateURL,
+}
+
+// validateOTLPEndpoint accepts the endpoints of the otlp exporters: either
+// host[:port], or an http or https URL whose scheme selects the transport
+// security.
+func validateOTLPEndpoint(endpoint string) error {
+	if strings.Contains(endpoint, "://") {
+		return validateURL(endpoint)
+	}
+	return validateHostPort(endpoint)
+}
+
+// validateHostPort accepts endpoints in the host[:port] form.
+func validateHostPort(endpoint string) error {
+	if endpoint == "" {
+		return ErrMissingEndpoint
+	}
+	if strings.Contains(endpoint, "/") {
+		return fmt.Errorf("%w: %q must be host[:port] without a path", ErrInvalidEndpoint, endpoint)
+	}
+
+	host := endpoint
+	if strings.Contains(endpoint, ":") {
+		h, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidEndpoint, err)
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("%w: %q has an invalid port", ErrInvalidEndpoint, endpoint)
+		}
+		host = h
+	}
+	if host == "" {
+		return fmt.Errorf("%w: %q is missing a host", ErrInvalidEndpoint, endpoint)
+	}
+	return nil
+}
+
+// validateURL accepts absolute http or https URLs, as expected by the jaeger
+// and zipkin exporters.
+func validateURL(endpoint string) error {
+	if endpoint == "" {
+		return ErrMissingEndpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEndpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: %q must be an http or https URL", ErrInvalidEndpoint, endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: %q is missing a host", ErrInvalidEndpoint, endpoint)
+	}
+	return nil
 }
 
 // TraceConfig will be used in TraceMiddleware to use config and TraceProvider
@@ -47,4 +370,13 @@ type Config struct {
 // (Deprecated). Consider using Tracing instead.
 type TraceConfig struct {
 	TraceProvider trace.TracerProvider
+
+	// SpanNameFormatter names the spans started by TraceMiddleware. The name
+	// is computed again once the request has been handled, so formatters based
+	// on the routes matched by an inner router, like ServeMuxSpanName, work.
+	// Defaults to PathSpanName.
+	SpanNameFormatter SpanNameFormatter
+
+	// Filters select requests that TraceMiddleware passes through untraced.
+	Filters []RequestFilter
 }
@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Config      Config
+		Errs        []error
+		Fields      []string
+	}{
+		{
+			Description: "Default",
+			Config:      Config{},
+		},
+		{
+			Description: "Otlp/gRPC: Valid",
+			Config: Config{
+				Provider:    "otlp/grpc",
+				Endpoint:    "localhost:4317",
+				ParentBased: "honor",
+				NoParent:    "always",
+			},
+		},
+		{
+			Description: "Otlp/HTTP: Valid without port",
+			Config: Config{
+				Provider: "OTLP/HTTP",
+				Endpoint: "collector.example.com",
+			},
+		},
+		{
+			Description: "Otlp/gRPC: Valid URL",
+			Config: Config{
+				Provider: "otlp/grpc",
+				Endpoint: "http://localhost",
+			},
+		},
+		{
+			Description: "Otlp/gRPC: Scheme not supported",
+			Config: Config{
+				Provider: "otlp/grpc",
+				Endpoint: "grpc://localhost:4317",
+			},
+			Errs:   []error{ErrInvalidEndpoint},
+			Fields: []string{"endpoint"},
+		},
+		{
+			Description: "Otlp/HTTP: Path not allowed",
+			Config: Config{
+				Provider: "otlp/http",
+				Endpoint: "localhost:4318/v1/traces",
+			},
+			Errs:   []error{ErrInvalidEndpoint},
+			Fields: []string{"endpoint"},
+		},
+		{
+			Description: "Otlp/HTTP: Bad port",
+			Config: Config{
+				Provider: "otlp/http",
+				Endpoint: "localhost:http",
+			},
+			Errs:   []error{ErrInvalidEndpoint},
+			Fields: []string{"endpoint"},
+		},
+		{
+			Description: "Jaeger: Valid",
+			Config: Config{
+				Provider: "jaeger",
+				Endpoint: "http://localhost:14268/api/traces",
+			},
+		},
+		{
+			Description: "Zipkin: Missing scheme",
+			Config: Config{
+				Provider: "zipkin",
+				Endpoint: "localhost:9411",
+			},
+			Errs:   []error{ErrInvalidEndpoint},
+			Fields: []string{"endpoint"},
+		},
+		{
+			Description: "Jaeger: Missing endpoint",
+			Config: Config{
+				Provider: "jaeger",
+			},
+			Errs:   []error{ErrMissingEndpoint},
+			Fields: []string{"endpoint"},
+		},
+		{
+			Description: "Stdout: Endpoint ignored",
+			Config: Config{
+				Provider: "stdout",
+				Endpoint: "::not a url::",
+			},
+		},
+		{
+			Description: "Custom provider skips endpoint checks",
+			Config: Config{
+				Provider: "otlp/grpc",
+				Providers: map[string]ProviderConstructor{
+					"otlp/grpc": func(_ Config, _ sdktrace.Sampler) (trace.TracerProvider, error) {
+						return noop.NewTracerProvider(), nil
+					},
+				},
+			},
+		},
+		{
+			Description: "NoParent unused when parents are ignored",
+			Config: Config{
+				ParentBased: "ignore",
+				NoParent:    "sometimes",
+			},
+		},
+		{
+			Description: "NoParent checked when parents are honored",
+			Config: Config{
+				ParentBased: "honor",
+				NoParent:    "sometimes",
+			},
+			Errs:   []error{ErrInvalidNoParentValue},
+			Fields: []string{"noParent"},
+		},
+		{
+			Description: "Every problem is reported",
+			Config: Config{
+				Provider:    "otlp/grpc",
+				ParentBased: "dishonor",
+				NoParent:    "sometimes",
+			},
+			Errs:   []error{ErrInvalidParentBasedValue, ErrInvalidNoParentValue, ErrMissingEndpoint},
+			Fields: []string{"parentBased", "noParent", "endpoint"},
+		},
+		{
+			Description: "Invalid exporter settings",
+			Config: Config{
+				Provider:    "otlp/http",
+				Endpoint:    "localhost:4318",
+				Compression: "zstd",
+				Timeout:     -time.Second,
+				URLPath:     "v1/traces",
+				Retry:       RetryConfig{InitialInterval: -time.Second},
+			},
+			Errs:   []error{ErrInvalidCompression, ErrInvalidTimeout, ErrInvalidURLPath, ErrInvalidRetryValue},
+			Fields: []string{"compression", "timeout", "urlPath", "retry.initialInterval"},
+		},
+		{
+			Description: "Invalid tail sampling",
+			Config: Config{
+				TailSampling: TailSamplingConfig{Enabled: true, MaxTraces: -1},
+			},
+			Errs:   []error{ErrInvalidTailSamplingValue, ErrInvalidTailSamplingValue},
+			Fields: []string{"tailSampling.maxTraces", "tailSampling.enabled"},
+		},
+		{
+			Description: "Invalid device sampling",
+			Config: Config{
+				DeviceSampling: DeviceSamplingConfig{Devices: []string{"talaria"}, Ratio: 2},
+			},
+			Errs:   []error{ErrInvalidDeviceSamplingValue, ErrInvalidDeviceSamplingValue},
+			Fields: []string{"deviceSampling.devices[0]", "deviceSampling.ratio"},
+		},
+		{
+			Description: "Invalid error limit",
+			Config: Config{
+				ErrorLimit: ErrorLimit{Burst: -1, Interval: -time.Second},
+			},
+			Errs:   []error{ErrInvalidErrorLimit, ErrInvalidErrorLimit},
+			Fields: []string{"errorLimit.burst", "errorLimit.interval"},
+		},
+		{
+			Description: "Invalid redaction rule",
+			Config: Config{
+				Redactions: []RedactionRule{
+					{Key: "wrp.*", Action: RedactHash},
+					{Value: "(", Action: RedactMask},
+				},
+			},
+			Errs:   []error{ErrInvalidRedactionPattern},
+			Fields: []string{"redactions[1]"},
+		},
+		{
+			Description: "Unknown provider",
+			Config: Config{
+				Provider:    "undefined",
+				ParentBased: "honor",
+				NoParent:    "sometimes",
+			},
+			Errs:   []error{ErrTracerProviderNotFound, ErrInvalidNoParentValue},
+			Fields: []string{"provider", "noParent"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			var (
+				assert  = assert.New(t)
+				require = require.New(t)
+				err     = tc.Config.Validate()
+			)
+			if len(tc.Errs) == 0 {
+				assert.NoError(err)
+				return
+			}
+			require.Error(err)
+
+			joined, ok := err.(interface{ Unwrap() []error })
+			require.True(ok)
+			var fields []string
+			for _, e := range joined.Unwrap() {
+				var fe *FieldError
+				require.True(errors.As(e, &fe))
+				fields = append(fields, fe.Field)
+			}
+			assert.Equal(tc.Fields, fields)
+			for _, expected := range tc.Errs {
+				assert.ErrorIs(err, expected)
+			}
+		})
+	}
+}
+
+func TestSpanLimitsDefaults(t *testing.T) {
+	assert := assert.New(t)
+	limits := SpanLimits{}.sdkSpanLimits()
+	assert.Equal(DefaultAttributeValueLengthLimit, limits.AttributeValueLengthLimit)
+	assert.Equal(sdktrace.DefaultAttributeCountLimit, limits.AttributeCountLimit)
+	assert.Equal(sdktrace.DefaultEventCountLimit, limits.EventCountLimit)
+	assert.Equal(sdktrace.DefaultLinkCountLimit, limits.LinkCountLimit)
+	assert.Equal(sdktrace.DefaultAttributePerEventCountLimit, limits.AttributePerEventCountLimit)
+	assert.Equal(sdktrace.DefaultAttributePerLinkCountLimit, limits.AttributePerLinkCountLimit)
+}
+
+func TestSpanLimitsOverrides(t *testing.T) {
+	limits := SpanLimits{
+		AttributeValueLengthLimit:   -1,
+		AttributeCountLimit:         1,
+		EventCountLimit:             2,
+		LinkCountLimit:              3,
+		AttributePerEventCountLimit: 4,
+		AttributePerLinkCountLimit:  5,
+	}.sdkSpanLimits()
+	assert.Equal(t, sdktrace.SpanLimits{
+		AttributeValueLengthLimit:   -1,
+		AttributeCountLimit:         1,
+		EventCountLimit:             2,
+		LinkCountLimit:              3,
+		AttributePerEventCountLimit: 4,
+		AttributePerLinkCountLimit:  5,
+	}, limits)
+}
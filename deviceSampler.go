@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpcontext"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var ErrInvalidDeviceSamplingValue = errors.New("invalid device sampling value provided in configuration")
+
+// DeviceSamplingConfig configures sampling every request concerning some
+// devices, whatever the parentBased and noParent settings. Devices are
+// identified by the source and destination of the WRP message found in the
+// context, as stored by EchoFirstTraceNodeInfo when decoding requests, or by
+// the device-id baggage member. The decision only depends on the device ID,
+// so every service with the same configuration samples the same devices.
+type DeviceSamplingConfig struct {
+	// Devices lists the IDs of the devices always sampled, in any form
+	// accepted by wrp.ParseDeviceID, e.g. "mac:112233445566".
+	Devices []string `json:"devices"`
+
+	// Ratio is the fraction, between 0 and 1, of all devices sampled. The
+	// devices are picked by hashing their ID.
+	Ratio float64 `json:"ratio"`
+}
+
+// enabled reports whether the configuration is set.
+func (c DeviceSamplingConfig) enabled() bool {
+	return len(c.Devices) > 0 || c.Ratio != 0
+}
+
+// validate reports the invalid fields of the device sampling configuration.
+func (c DeviceSamplingConfig) validate() []error {
+	var errs []error
+	for i, d := range c.Devices {
+		if _, ok := parseSampledDeviceID(d); !ok {
+			errs = append(errs, &FieldError{
+				Field: fmt.Sprintf("deviceSampling.devices[%d]", i),
+				Err:   fmt.Errorf("%w: %q is not a device ID", ErrInvalidDeviceSamplingValue, d),
+			})
+		}
+	}
+	if c.Ratio < 0 || c.Ratio > 1 {
+		errs = append(errs, &FieldError{
+			Field: "deviceSampling.ratio",
+			Err:   fmt.Errorf("%w: %v is not between 0 and 1", ErrInvalidDeviceSamplingValue, c.Ratio),
+		})
+	}
+	return errs
+}
+
+// parseSampledDeviceID canonicalizes the device ID of a WRP locator. Server
+// locators, using the dns or self schemes, are not device IDs.
+func parseSampledDeviceID(locator string) (wrp.DeviceID, bool) {
+	id, err := wrp.ParseDeviceID(locator)
+	if err != nil {
+		return "", false
+	}
+	switch id.Prefix() {
+	case "mac", "uuid", "serial":
+		return id, true
+	}
+	return "", false
+}
+
+// deviceSampler samples the spans of some devices, deferring to another
+// sampler for the rest.
+type deviceSampler struct {
+	devices   map[wrp.DeviceID]struct{}
+	threshold uint64
+	ratio     float64
+	fallback  sdktrace.Sampler
+}
+
+// NewDeviceSampler returns a sampler recording and sampling the spans started
+// for the devices selected by config, and delegating to fallback for the
+// others. ConfigureTracerProvider installs it automatically when
+// Config.DeviceSampling is set.
+func NewDeviceSampler(config DeviceSamplingConfig, fallback sdktrace.Sampler) (sdktrace.Sampler, error) {
+	if err := errors.Join(config.validate()...); err != nil {
+		return nil, err
+	}
+	s := &deviceSampler{
+		devices:  make(map[wrp.DeviceID]struct{}, len(config.Devices)),
+		ratio:    config.Ratio,
+		fallback: fallback,
+	}
+	for _, d := range config.Devices {
+		id, _ := parseSampledDeviceID(d)
+		s.devices[id] = struct{}{}
+	}
+	// Hashes are compared on 53 bits, the precision of a float64.
+	s.threshold = uint64(config.Ratio * (1 << 53))
+	return s, nil
+}
+
+func (s *deviceSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, id := range contextDeviceIDs(p.ParentContext) {
+		if s.samples(id) {
+			return sdktrace.SamplingResult{
+				Decision:   sdktrace.RecordAndSample,
+				Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+			}
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *deviceSampler) samples(id wrp.DeviceID) bool {
+	if _, ok := s.devices[id]; ok {
+		return true
+	}
+	if s.threshold == 0 {
+		return false
+	}
+	h := sha256.Sum256([]byte(id))
+	return binary.BigEndian.Uint64(h[:8])>>11 < s.threshold
+}
+
+func (s *deviceSampler) Description() string {
+	return fmt.Sprintf("DeviceSampler{devices:%d,ratio:%g}/%s", len(s.devices), s.ratio, s.fallback.Description())
+}
+
+// contextDeviceIDs returns the device IDs found in the WRP message and the
+// baggage of ctx.
+func contextDeviceIDs(ctx context.Context) []wrp.DeviceID {
+	var ids []wrp.DeviceID
+	add := func(locator string) {
+		if id, ok := parseSampledDeviceID(locator); ok {
+			ids = append(ids, id)
+		}
+	}
+	if msg, ok := wrpcontext.GetMessage(ctx); ok && msg != nil {
+		add(msg.Source)
+		add(msg.Destination)
+	}
+	if m := baggage.FromContext(ctx).Member(DeviceIDBaggageKey); m.Key() != "" {
+		add(m.Value())
+	}
+	return ids
+}
+
+// withDeviceIDBaggage returns a copy of ctx whose baggage carries the device
+// ID of the WRP message in ctx, unless the baggage already has one.
+func withDeviceIDBaggage(ctx context.Context) context.Context {
+	if m := baggage.FromContext(ctx).Member(DeviceIDBaggageKey); m.Key() != "" {
+		return ctx
+	}
+	ids := contextDeviceIDs(ctx)
+	if len(ids) == 0 {
+		return ctx
+	}
+	ctx, _ = setBaggageMember(ctx, DeviceIDBaggageKey, string(ids[0]))
+	return ctx
+}
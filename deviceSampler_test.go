@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpcontext"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func wrpContext(source, destination string) context.Context {
+	return wrpcontext.SetMessage(context.Background(), &wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      source,
+		Destination: destination,
+	})
+}
+
+func deviceBaggageContext(t *testing.T, deviceID string) context.Context {
+	ctx, err := setBaggageMember(context.Background(), DeviceIDBaggageKey, deviceID)
+	require.NoError(t, err)
+	return ctx
+}
+
+func TestDeviceSampler(t *testing.T) {
+	allowlist := DeviceSamplingConfig{Devices: []string{"mac:112233445566", "serial:ABC123"}}
+	tcs := []struct {
+		Description string
+		Config      DeviceSamplingConfig
+		Context     context.Context
+		Sampled     bool
+	}{
+		{
+			Description: "Listed source",
+			Config:      allowlist,
+			Context:     wrpContext("MAC:11:22:33:44:55:66/config", "dns:talaria.example.com"),
+			Sampled:     true,
+		},
+		{
+			Description: "Listed destination",
+			Config:      allowlist,
+			Context:     wrpContext("dns:scytale.example.com", "serial:ABC123/iot"),
+			Sampled:     true,
+		},
+		{
+			Description: "Listed baggage device ID",
+			Config:      allowlist,
+			Context:     deviceBaggageContext(t, "mac:112233445566"),
+			Sampled:     true,
+		},
+		{
+			Description: "Unlisted device",
+			Config:      allowlist,
+			Context:     wrpContext("mac:665544332211", "event:device-status"),
+		},
+		{
+			Description: "No device",
+			Config:      allowlist,
+			Context:     context.Background(),
+		},
+		{
+			Description: "All devices",
+			Config:      DeviceSamplingConfig{Ratio: 1},
+			Context:     wrpContext("uuid:0a1b2c", ""),
+			Sampled:     true,
+		},
+		{
+			Description: "Servers are not devices",
+			Config:      DeviceSamplingConfig{Ratio: 1},
+			Context:     wrpContext("dns:talaria.example.com", "self:/service"),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			sampler, err := NewDeviceSampler(tc.Config, sdktrace.NeverSample())
+			require.NoError(t, err)
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: tc.Context, Name: "span"})
+			if tc.Sampled {
+				assert.Equal(t, sdktrace.RecordAndSample, result.Decision)
+			} else {
+				assert.Equal(t, sdktrace.Drop, result.Decision)
+			}
+		})
+	}
+}
+
+func TestDeviceSamplerRatio(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sampler, err := NewDeviceSampler(DeviceSamplingConfig{Ratio: 0.25}, sdktrace.NeverSample())
+	require.NoError(err)
+	other, err := NewDeviceSampler(DeviceSamplingConfig{Ratio: 0.25}, sdktrace.NeverSample())
+	require.NoError(err)
+
+	sampled := 0
+	for i := range 2000 {
+		p := sdktrace.SamplingParameters{ParentContext: wrpContext(fmt.Sprintf("mac:%012x", i), "")}
+		decision := sampler.ShouldSample(p).Decision
+		assert.Equal(decision, other.ShouldSample(p).Decision, "decisions must be the same in every service")
+		if decision == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+	assert.InDelta(500, sampled, 100)
+}
+
+func TestDeviceSamplerTraceState(t *testing.T) {
+	state, err := trace.ParseTraceState("rojo=00f067aa0ba902b7")
+	require.NoError(t, err)
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceState: state,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(wrpContext("mac:112233445566", ""), parent)
+
+	sampler, err := NewDeviceSampler(DeviceSamplingConfig{Devices: []string{"mac:112233445566"}}, sdktrace.NeverSample())
+	require.NoError(t, err)
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: ctx, TraceID: parent.TraceID()})
+	assert.Equal(t, sdktrace.RecordAndSample, result.Decision)
+	assert.Equal(t, state, result.Tracestate)
+	assert.Equal(t, "DeviceSampler{devices:1,ratio:0}/AlwaysOffSampler", sampler.Description())
+}
+
+func TestNewDeviceSamplerInvalid(t *testing.T) {
+	_, err := NewDeviceSampler(DeviceSamplingConfig{Devices: []string{"mac:112233445566", "dns:example.com"}, Ratio: 1.5}, sdktrace.NeverSample())
+	assert.ErrorIs(t, err, ErrInvalidDeviceSamplingValue)
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+	var fields []string
+	for _, e := range joined.Unwrap() {
+		var fe *FieldError
+		require.True(t, errors.As(e, &fe))
+		fields = append(fields, fe.Field)
+	}
+	assert.Equal(t, []string{"deviceSampling.devices[1]", "deviceSampling.ratio"}, fields)
+}
+
+func TestConfigureTracerProviderDeviceSampling(t *testing.T) {
+	tcs := []Config{
+		{Provider: "otlp/grpc", Endpoint: "localhost:4317"},
+		{Provider: "otlp/http", Endpoint: "localhost:4318"},
+		{Provider: "jaeger", Endpoint: "http://localhost:14268/api/traces"},
+		{Provider: "zipkin", Endpoint: "http://localhost:9411/api/v2/spans"},
+		{Provider: "stdout", SkipTraceExport: true},
+	}
+	for _, config := range tcs {
+		t.Run(config.Provider, func(t *testing.T) {
+			config.ParentBased = "ignore"
+			config.DeviceSampling = DeviceSamplingConfig{Devices: []string{"mac:112233445566"}}
+			tp, err := ConfigureTracerProvider(config)
+			require.NoError(t, err)
+			defer func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_ = tp.(*sdktrace.TracerProvider).Shutdown(ctx)
+			}()
+
+			_, span := tp.Tracer("test").Start(wrpContext("mac:112233445566", ""), "device")
+			assert.True(t, span.SpanContext().IsSampled(), "listed devices are sampled even when parentBased is ignore")
+			_, span = tp.Tracer("test").Start(wrpContext("mac:665544332211", ""), "other")
+			assert.False(t, span.SpanContext().IsSampled())
+		})
+	}
+
+	_, err := ConfigureTracerProvider(Config{DeviceSampling: DeviceSamplingConfig{Ratio: -1}})
+	assert.ErrorIs(t, err, ErrInvalidDeviceSamplingValue)
+}
+
+func TestEchoFirstTraceNodeInfoDeviceIDBaggage(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Context     context.Context
+		Options     []MiddlewareOption
+		Expected    string
+	}{
+		{
+			Description: "Source device",
+			Context:     wrpContext("mac:11:22:33:44:55:66/config", "dns:talaria.example.com"),
+			Options:     []MiddlewareOption{WithDeviceIDBaggage()},
+			Expected:    "mac:112233445566",
+		},
+		{
+			Description: "Destination device",
+			Context:     wrpContext("dns:scytale.example.com", "serial:ABC123/iot"),
+			Options:     []MiddlewareOption{WithDeviceIDBaggage()},
+			Expected:    "serial:ABC123",
+		},
+		{
+			Description: "Existing baggage kept",
+			Context:     wrpcontext.SetMessage(deviceBaggageContext(t, "mac:aabbccddeeff"), &wrp.Message{Source: "mac:112233445566"}),
+			Options:     []MiddlewareOption{WithDeviceIDBaggage()},
+			Expected:    "mac:aabbccddeeff",
+		},
+		{
+			Description: "No device",
+			Context:     wrpContext("dns:scytale.example.com", "event:device-status"),
+			Options:     []MiddlewareOption{WithDeviceIDBaggage()},
+		},
+		{
+			Description: "Disabled",
+			Context:     wrpContext("mac:112233445566", ""),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			var deviceID string
+			handler := EchoFirstTraceNodeInfo(Tracing{}, false, tc.Options...)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				deviceID = baggage.FromContext(r.Context()).Member(DeviceIDBaggageKey).Value()
+			}))
+			r := httptest.NewRequest(http.MethodPost, "/", nil).WithContext(tc.Context)
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tc.Expected, deviceID)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Error limit defaults used for the zero values of ErrorLimit.
+const (
+	DefaultErrorLimitBurst    = 10
+	DefaultErrorLimitInterval = time.Minute
+)
+
+var (
+	ErrInvalidErrorLimit = errors.New("invalid error limit value provided in configuration")
+	ErrSpanExportFailed  = errors.New("failed exporting spans")
+	ErrErrorsSuppressed  = errors.New("error limit reached")
+)
+
+// ErrorLimit bounds the rate of the errors passed to Config.ErrorHandler,
+// so that an unreachable endpoint does not flood the logs.
+type ErrorLimit struct {
+	// Burst is the number of errors reported per Interval. Defaults to 10.
+	Burst int `json:"burst"`
+
+	// Interval is the period over which Burst errors are reported. Defaults
+	// to 1m.
+	Interval time.Duration `json:"interval"`
+}
+
+// validate reports the invalid fields of the error limit.
+func (l ErrorLimit) validate() []error {
+	var errs []error
+	if l.Burst < 0 {
+		errs = append(errs, &FieldError{
+			Field: "errorLimit.burst",
+			Err:   fmt.Errorf("%w: negative count", ErrInvalidErrorLimit),
+		})
+	}
+	if l.Interval < 0 {
+		errs = append(errs, &FieldError{
+			Field: "errorLimit.interval",
+			Err:   fmt.Errorf("%w: negative duration", ErrInvalidErrorLimit),
+		})
+	}
+	return errs
+}
+
+func (l ErrorLimit) withDefaults() ErrorLimit {
+	if l.Burst == 0 {
+		l.Burst = DefaultErrorLimitBurst
+	}
+	if l.Interval == 0 {
+		l.Interval = DefaultErrorLimitInterval
+	}
+	return l
+}
+
+// TracingError is the error passed to Config.ErrorHandler. For the errors of
+// an exporter, it tells which provider and endpoint the wrapped error is
+// about; Provider and Endpoint are empty for the other errors.
+type TracingError struct {
+	Provider string
+	Endpoint string
+
+	// Suppressed is the number of errors left out by the ErrorLimit since
+	// the previous one was reported.
+	Suppressed int
+
+	Err error
+}
+
+func (e *TracingError) Error() string {
+	msg := "tracing"
+	if e.Provider != "" {
+		msg += " provider " + e.Provider
+	}
+	if e.Endpoint != "" {
+		msg += " (endpoint " + e.Endpoint + ")"
+	}
+	msg += ": " + e.Err.Error()
+	if e.Suppressed > 0 {
+		msg += fmt.Sprintf(" [%d more errors suppressed]", e.Suppressed)
+	}
+	return msg
+}
+
+func (e *TracingError) Unwrap() error {
+	return e.Err
+}
+
+// errorReporter passes errors to an error handler within the bounds of an
+// ErrorLimit. The errors of the exporter are labeled with its provider and
+// endpoint.
+type errorReporter struct {
+	handler  otel.ErrorHandler
+	provider string
+	endpoint string
+	limit    ErrorLimit
+	now      func() time.Time
+
+	mu         sync.Mutex
+	window     time.Time
+	reported   int
+	suppressed int
+
+	// exported is the last export error reported, which the span
+	// processors also pass to the global error handler.
+	exported error
+}
+
+// newErrorReporter returns the reporter of the errors of the provider
+// described by config. The exporter given with WithExporter, if any, is named
+// after its type.
+func newErrorReporter(config Config) *errorReporter {
+	provider, endpoint := strings.ToLower(config.Provider), config.Endpoint
+	if provider == "" {
+		provider = DefaultTracerProvider
+	}
+	if config.options.exporter != nil {
+		provider, endpoint = fmt.Sprintf("%T", config.options.exporter), ""
+	}
+	return &errorReporter{
+		handler:  config.ErrorHandler,
+		provider: provider,
+		endpoint: endpoint,
+		limit:    config.ErrorLimit.withDefaults(),
+		now:      time.Now,
+	}
+}
+
+// Handle reports err unless the limit has been reached or err is an export
+// error already reported. It wraps err in a TracingError only to carry the
+// number of suppressed errors.
+func (r *errorReporter) Handle(err error) {
+	if err == nil {
+		return
+	}
+	suppressed, ok := r.allow(err)
+	if !ok {
+		return
+	}
+	if suppressed > 0 {
+		err = &TracingError{Suppressed: suppressed, Err: err}
+	}
+	r.handler.Handle(err)
+}
+
+// exportFailed reports err, the error of exporting n spans, as a TracingError
+// naming the provider and endpoint, unless the limit has been reached.
+func (r *errorReporter) exportFailed(err error, n int) {
+	r.mu.Lock()
+	r.exported = err
+	r.mu.Unlock()
+
+	suppressed, ok := r.allow(nil)
+	if !ok {
+		return
+	}
+	r.handler.Handle(&TracingError{
+		Provider:   r.provider,
+		Endpoint:   r.endpoint,
+		Suppressed: suppressed,
+		Err:        fmt.Errorf("%w: %d spans: %w", ErrSpanExportFailed, n, err),
+	})
+}
+
+// allow reports whether an error can be passed to the handler and, if so, the
+// number of errors suppressed before it. It refuses err if it is the last
+// export error, which has been reported already.
+func (r *errorReporter) allow(err error) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil && r.exported != nil && errors.Is(err, r.exported) {
+		return 0, false
+	}
+	if now := r.now(); now.Sub(r.window) >= r.limit.Interval {
+		r.window = now
+		r.reported = 0
+	}
+	if r.reported >= r.limit.Burst {
+		r.suppressed++
+		return 0, false
+	}
+	r.reported++
+	suppressed := r.suppressed
+	r.suppressed = 0
+	return suppressed, true
+}
+
+// flush reports the number of errors suppressed since the last one was
+// reported, if any, regardless of the limit.
+func (r *errorReporter) flush() {
+	r.mu.Lock()
+	suppressed := r.suppressed
+	r.suppressed = 0
+	r.mu.Unlock()
+
+	if suppressed > 0 {
+		r.handler.Handle(&TracingError{Suppressed: suppressed, Err: ErrErrorsSuppressed})
+	}
+}
+
+// reportingExporter reports failed exports to an error reporter.
+type reportingExporter struct {
+	next     sdktrace.SpanExporter
+	reporter *errorReporter
+}
+
+// ExportSpans reports a failed export and returns its error, so that
+// ForceFlush and Shutdown callers still see it. The span processors also pass
+// that error to the global OpenTelemetry error handler, which skips it when
+// it is the reporter, as installed by New.
+func (e *reportingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.next.ExportSpans(ctx, spans)
+	if err != nil {
+		e.reporter.exportFailed(err, len(spans))
+	}
+	return err
+}
+
+// Shutdown reports the number of errors still suppressed, which would
+// otherwise never be reported, before shutting down the exporter.
+func (e *reportingExporter) Shutdown(ctx context.Context) error {
+	e.reporter.flush()
+	return e.next.Shutdown(ctx)
+}
+
+// WithLogger sets Config.ErrorHandler to log errors to logger, with the
+// provider, endpoint and number of suppressed errors of TracingErrors as
+// attributes.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.errorHandler = otel.ErrorHandlerFunc(func(err error) {
+			attrs := []any{slog.Any("error", err)}
+			var te *TracingError
+			if errors.As(err, &te) {
+				attrs = []any{slog.Any("error", te.Err)}
+				if te.Provider != "" {
+					attrs = append(attrs,
+						slog.String("provider", te.Provider),
+						slog.String("endpoint", te.Endpoint),
+					)
+				}
+				if te.Suppressed > 0 {
+					attrs = append(attrs, slog.Int("suppressed", te.Suppressed))
+				}
+			}
+			logger.Error("tracing error", attrs...)
+		})
+	}
+}
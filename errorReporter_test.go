@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/candlelight/collectortest"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// errorRecorder is an error handler keeping the errors it receives.
+type errorRecorder struct {
+	errs []error
+}
+
+func (r *errorRecorder) Handle(err error) {
+	r.errs = append(r.errs, err)
+}
+
+func TestTracingError(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Err         TracingError
+		Expected    string
+	}{
+		{
+			Description: "With endpoint",
+			Err:         TracingError{Provider: "otlp/http", Endpoint: "localhost:4318", Err: errExportFailed},
+			Expected:    "tracing provider otlp/http (endpoint localhost:4318): export failed",
+		},
+		{
+			Description: "Without endpoint",
+			Err:         TracingError{Provider: "stdout", Err: errExportFailed},
+			Expected:    "tracing provider stdout: export failed",
+		},
+		{
+			Description: "Suppressed errors",
+			Err:         TracingError{Provider: "stdout", Suppressed: 3, Err: errExportFailed},
+			Expected:    "tracing provider stdout: export failed [3 more errors suppressed]",
+		},
+		{
+			Description: "Without provider",
+			Err:         TracingError{Suppressed: 1, Err: errExportFailed},
+			Expected:    "tracing: export failed [1 more errors suppressed]",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tc.Expected, tc.Err.Error())
+			assert.ErrorIs(&tc.Err, errExportFailed)
+		})
+	}
+}
+
+func TestErrorReporterLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var recorder errorRecorder
+	now := time.Now()
+	reporter := newErrorReporter(Config{
+		Provider:     "OTLP/HTTP",
+		Endpoint:     "localhost:4318",
+		ErrorHandler: &recorder,
+		ErrorLimit:   ErrorLimit{Burst: 2, Interval: time.Minute},
+	})
+	reporter.now = func() time.Time { return now }
+
+	reporter.Handle(nil)
+	for range 5 {
+		reporter.Handle(errExportFailed)
+	}
+	require.Len(recorder.errs, 2)
+	assert.Equal(errExportFailed, recorder.errs[0], "other errors are not labeled")
+
+	now = now.Add(time.Minute)
+	reporter.Handle(errExportFailed)
+	require.Len(recorder.errs, 3)
+	assert.Equal(&TracingError{Suppressed: 3, Err: errExportFailed}, recorder.errs[2])
+
+	for range 3 {
+		reporter.Handle(errExportFailed)
+	}
+	require.Len(recorder.errs, 4)
+	reporter.flush()
+	require.Len(recorder.errs, 5)
+	assert.Equal(&TracingError{Suppressed: 2, Err: ErrErrorsSuppressed}, recorder.errs[4])
+	reporter.flush()
+	assert.Len(recorder.errs, 5, "nothing left to flush")
+}
+
+func TestErrorReporterExportFailed(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var recorder errorRecorder
+	reporter := newErrorReporter(Config{
+		Provider:     "OTLP/HTTP",
+		Endpoint:     "localhost:4318",
+		ErrorHandler: &recorder,
+	})
+
+	reporter.exportFailed(errExportFailed, 3)
+	reporter.Handle(errExportFailed)
+	require.Len(recorder.errs, 1, "the export error is reported once")
+
+	var te *TracingError
+	require.ErrorAs(recorder.errs[0], &te)
+	assert.Equal("otlp/http", te.Provider)
+	assert.Equal("localhost:4318", te.Endpoint)
+	assert.Zero(te.Suppressed)
+	assert.ErrorIs(te, ErrSpanExportFailed)
+	assert.ErrorIs(te, errExportFailed)
+	assert.Contains(te.Error(), "3 spans")
+
+	other := errors.New("dropped spans")
+	reporter.Handle(other)
+	require.Len(recorder.errs, 2)
+	assert.Equal(other, recorder.errs[1])
+}
+
+func TestReportingExporter(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var recorder errorRecorder
+	reporter := newErrorReporter(Config{
+		ErrorHandler: &recorder,
+		ErrorLimit:   ErrorLimit{Burst: 1, Interval: time.Hour},
+	})
+	exporter := &reportingExporter{next: &flakyExporter{failures: 3}, reporter: reporter}
+
+	for range 3 {
+		assert.ErrorIs(exporter.ExportSpans(context.Background(), nil), errExportFailed)
+	}
+	assert.NoError(exporter.ExportSpans(context.Background(), nil))
+	require.Len(recorder.errs, 1)
+
+	require.NoError(exporter.Shutdown(context.Background()))
+	require.Len(recorder.errs, 2)
+	assert.Equal(&TracingError{Suppressed: 2, Err: ErrErrorsSuppressed}, recorder.errs[1])
+}
+
+func TestNewErrorReporterProvider(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Config      Config
+		Provider    string
+		Endpoint    string
+	}{
+		{
+			Description: "Default provider",
+			Config:      Config{},
+			Provider:    DefaultTracerProvider,
+		},
+		{
+			Description: "Built-in provider",
+			Config:      Config{Provider: "OTLP/gRPC", Endpoint: "localhost:4317"},
+			Provider:    "otlp/grpc",
+			Endpoint:    "localhost:4317",
+		},
+		{
+			Description: "Exporter option",
+			Config: Config{
+				Provider: "stdout",
+				Endpoint: "ignored",
+				options:  options{exporter: tracetest.NewInMemoryExporter()},
+			},
+			Provider: "*tracetest.InMemoryExporter",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			reporter := newErrorReporter(tc.Config)
+			assert.Equal(tc.Provider, reporter.provider)
+			assert.Equal(tc.Endpoint, reporter.endpoint)
+			assert.Equal(DefaultErrorLimitBurst, reporter.limit.Burst)
+			assert.Equal(DefaultErrorLimitInterval, reporter.limit.Interval)
+		})
+	}
+}
+
+func TestNewErrorHandlerExportFailure(t *testing.T) {
+	tcs := []struct {
+		Description    string
+		RegisterGlobal bool
+	}{
+		{Description: "Registered globally", RegisterGlobal: true},
+		{Description: "Not registered globally"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			keepGlobals(t)
+
+			var global int
+			otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) { global++ }))
+
+			collector, err := collectortest.New()
+			require.NoError(err)
+			defer collector.Close()
+			collector.Reject(true)
+
+			var recorder errorRecorder
+			tracing, err := New(Config{
+				Provider:       "otlp/http",
+				Endpoint:       collector.HTTPEndpoint,
+				ParentBased:    "honor",
+				NoParent:       "always",
+				RegisterGlobal: tc.RegisterGlobal,
+				ErrorHandler:   &recorder,
+			})
+			require.NoError(err)
+			tp, ok := tracing.TracerProvider().(*sdktrace.TracerProvider)
+			require.True(ok)
+			defer func() { _ = tp.Shutdown(context.Background()) }()
+			assert.Equal(tc.RegisterGlobal, otel.GetTracerProvider() == tracing.TracerProvider())
+
+			_, span := tp.Tracer("test").Start(context.Background(), "rejected")
+			span.End()
+			err = tp.ForceFlush(context.Background())
+			require.Error(err, "failures are returned")
+
+			require.Len(recorder.errs, 1)
+			var te *TracingError
+			require.ErrorAs(recorder.errs[0], &te)
+			assert.Equal("otlp/http", te.Provider)
+			assert.Equal(collector.HTTPEndpoint, te.Endpoint)
+			assert.ErrorIs(te, ErrSpanExportFailed)
+
+			// The batch span processor also passes the failures of its
+			// background exports to the global error handler.
+			otel.Handle(err)
+			assert.Len(recorder.errs, 1, "failures are reported once")
+			otel.Handle(errExportFailed)
+			require.Len(recorder.errs, 2, "processor errors reach the error handler")
+			assert.Equal(errExportFailed, recorder.errs[1], "other errors are not labeled")
+			assert.Zero(global)
+		})
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Err         error
+		Expected    map[string]any
+	}{
+		{
+			Description: "Tracing error",
+			Err:         &TracingError{Provider: "otlp/grpc", Endpoint: "localhost:4317", Err: errExportFailed},
+			Expected: map[string]any{
+				"provider": "otlp/grpc",
+				"endpoint": "localhost:4317",
+				"error":    "export failed",
+			},
+		},
+		{
+			Description: "Suppressed errors",
+			Err:         &TracingError{Provider: "stdout", Suppressed: 2, Err: errExportFailed},
+			Expected: map[string]any{
+				"provider":   "stdout",
+				"endpoint":   "",
+				"error":      "export failed",
+				"suppressed": float64(2),
+			},
+		},
+		{
+			Description: "Suppressed other errors",
+			Err:         &TracingError{Suppressed: 2, Err: errExportFailed},
+			Expected: map[string]any{
+				"error":      "export failed",
+				"suppressed": float64(2),
+			},
+		},
+		{
+			Description: "Other error",
+			Err:         errors.New("dropped spans"),
+			Expected:    map[string]any{"error": "dropped spans"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var buf bytes.Buffer
+			var o options
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, nil)))(&o)
+			require.NotNil(o.errorHandler)
+			o.errorHandler.Handle(tc.Err)
+
+			var record map[string]any
+			require.NoError(json.Unmarshal(buf.Bytes(), &record))
+			assert.Equal("ERROR", record["level"])
+			assert.Equal("tracing error", record["msg"])
+			delete(record, "time")
+			delete(record, "level")
+			delete(record, "msg")
+			assert.Equal(tc.Expected, record)
+		})
+	}
+}
+
+func TestNewWithLogger(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	keepGlobals(t)
+
+	var buf bytes.Buffer
+	exporter := &flakyExporter{failures: 1}
+	tracing, err := New(
+		Config{ParentBased: "honor", NoParent: "always"},
+		WithExporter(exporter),
+		WithLogger(slog.New(slog.NewTextHandler(&buf, nil))),
+	)
+	require.NoError(err)
+	tp, ok := tracing.TracerProvider().(*sdktrace.TracerProvider)
+	require.True(ok)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	require.ErrorIs(tp.ForceFlush(context.Background()), errExportFailed)
+	require.NoError(tp.Shutdown(context.Background()))
+
+	assert.Contains(buf.String(), "tracing error")
+	assert.Contains(buf.String(), "provider=*candlelight.flakyExporter")
+	assert.Contains(buf.String(), "export failed")
+}
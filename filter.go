@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RequestFilter reports whether a request should bypass tracing, e.g.
+// health checks or metric scrapes.
+type RequestFilter func(*http.Request) bool
+
+// SkipPathPrefix skips requests whose URL path starts with one of prefixes.
+func SkipPathPrefix(prefixes ...string) RequestFilter {
+	return func(r *http.Request) bool {
+		for _, p := range prefixes {
+			if strings.HasPrefix(r.URL.Path, p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipMethod skips requests made with one of methods.
+func SkipMethod(methods ...string) RequestFilter {
+	return func(r *http.Request) bool {
+		for _, m := range methods {
+			if strings.EqualFold(r.Method, m) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipHeader skips requests carrying the header name. If values are given,
+// one of the header's values must also equal one of them.
+func SkipHeader(name string, values ...string) RequestFilter {
+	return func(r *http.Request) bool {
+		actual := r.Header.Values(name)
+		if len(values) == 0 {
+			return len(actual) != 0
+		}
+		for _, a := range actual {
+			for _, v := range values {
+				if a == v {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// skipTracing reports whether any of filters matches r.
+func skipTracing(filters []RequestFilter, r *http.Request) bool {
+	for _, f := range filters {
+		if f(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3/wrpcontext"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestRequestFilters(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Filter      RequestFilter
+		Method      string
+		Path        string
+		Header      http.Header
+		Expected    bool
+	}{
+		{Description: "Path prefix match", Filter: SkipPathPrefix("/metrics", "/health"), Path: "/health/ready", Expected: true},
+		{Description: "Path prefix miss", Filter: SkipPathPrefix("/metrics", "/health"), Path: "/api/v2/device"},
+		{Description: "Method match", Filter: SkipMethod(http.MethodOptions, "head"), Method: http.MethodHead, Expected: true},
+		{Description: "Method miss", Filter: SkipMethod(http.MethodOptions), Method: http.MethodGet},
+		{Description: "Header present", Filter: SkipHeader("X-Probe"), Header: http.Header{"X-Probe": {"1"}}, Expected: true},
+		{Description: "Header absent", Filter: SkipHeader("X-Probe")},
+		{
+			Description: "Header value match",
+			Filter:      SkipHeader("User-Agent", "kube-probe/1.30", "Prometheus/2.0"),
+			Header:      http.Header{"User-Agent": {"Prometheus/2.0"}},
+			Expected:    true,
+		},
+		{
+			Description: "Header value miss",
+			Filter:      SkipHeader("User-Agent", "kube-probe/1.30"),
+			Header:      http.Header{"User-Agent": {"curl/8.0"}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			method, path := tc.Method, tc.Path
+			if method == "" {
+				method = http.MethodGet
+			}
+			if path == "" {
+				path = "/"
+			}
+			r := httptest.NewRequest(method, path, nil)
+			for k, v := range tc.Header {
+				r.Header[k] = v
+			}
+			assert.Equal(t, tc.Expected, tc.Filter(r))
+		})
+	}
+}
+
+func TestEchoFirstTraceNodeInfoFilter(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Path        string
+		Traced      bool
+	}{
+		{Description: "Filtered", Path: "/health"},
+		{Description: "Not filtered", Path: "/api/v2/device", Traced: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			var (
+				sc      trace.SpanContext
+				decoded bool
+			)
+			handler := EchoFirstTraceNodeInfo(Tracing{}, true, WithRequestFilter(SkipPathPrefix("/health")))(
+				http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					sc = trace.SpanContextFromContext(r.Context())
+					_, decoded = wrpcontext.GetMessage(r.Context())
+				}))
+
+			r := httptest.NewRequest(http.MethodPost, tc.Path,
+				strings.NewReader(`{"msg_type":3,"source":"dns:talaria","dest":"mac:112233445566"}`))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("traceparent", testTraceParent)
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tc.Traced, sc.IsValid())
+			assert.True(t, decoded, "filters do not skip decoding")
+		})
+	}
+}
+
+func TestTraceMiddlewareFilter(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracing := newTestTracing(exporter)
+	tc := TraceConfig{
+		TraceProvider: tracing.TracerProvider(),
+		Filters:       []RequestFilter{SkipPathPrefix("/metrics")},
+	}
+	handler := tc.TraceMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for _, path := range []string{"/metrics", "/api/v2/device"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, path != "/metrics", rec.Header().Get(traceIDHeaderName) != "")
+	}
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "/api/v2/device", spans[0].Name)
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"go.opentelemetry.io/otel"
+)
+
+// SetGlobal installs the tracer provider, the propagator and, if any, the
+// error handler of tracing as the OpenTelemetry globals, so that libraries
+// calling otel.GetTracerProvider and otel.GetTextMapPropagator use them.
+// It returns a function putting the previous globals back, mostly useful in
+// tests. New calls SetGlobal when Config.RegisterGlobal is set; the returned
+// function is then available as Tracing.RestoreGlobal.
+//
+// Note that the first tracer provider and propagator set globally also
+// receive the calls made through the globals obtained before, even after
+// restore is called; this is how the OpenTelemetry globals work.
+func SetGlobal(tracing Tracing) (restore func()) {
+	tp, propagator, handler := otel.GetTracerProvider(), otel.GetTextMapPropagator(), otel.GetErrorHandler()
+
+	otel.SetTracerProvider(tracing.TracerProvider())
+	otel.SetTextMapPropagator(tracing.Propagator())
+	if tracing.errorHandler != nil {
+		otel.SetErrorHandler(tracing.errorHandler)
+	}
+
+	return func() {
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(propagator)
+		if tracing.errorHandler != nil {
+			otel.SetErrorHandler(handler)
+		}
+	}
+}
+
+// setGlobalErrorHandler installs handler as the OpenTelemetry global error
+// handler, returning a function putting the previous one back.
+func setGlobalErrorHandler(handler otel.ErrorHandler) (restore func()) {
+	previous := otel.GetErrorHandler()
+	otel.SetErrorHandler(handler)
+	return func() {
+		otel.SetErrorHandler(previous)
+	}
+}
+
+// RestoreGlobal puts back the OpenTelemetry globals replaced by New, when
+// Config.RegisterGlobal or Config.ErrorHandler is set. It does nothing for
+// other Tracings.
+func (t Tracing) RestoreGlobal() {
+	if t.globals != nil {
+		t.globals.restore()
+	}
+}
+
+// globalState holds what is needed to restore the globals replaced by New.
+// Tracing refers to it through a pointer so that Tracing stays comparable.
+type globalState struct {
+	restore func()
+}
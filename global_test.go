@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// keepGlobals puts the OpenTelemetry globals back at the end of a test.
+func keepGlobals(t *testing.T) {
+	tp, propagator, handler := otel.GetTracerProvider(), otel.GetTextMapPropagator(), otel.GetErrorHandler()
+	t.Cleanup(func() {
+		// Setting the default globals again is reported as an error.
+		if otel.GetTracerProvider() != tp {
+			otel.SetTracerProvider(tp)
+		}
+		if !assert.ObjectsAreEqual(otel.GetTextMapPropagator(), propagator) {
+			otel.SetTextMapPropagator(propagator)
+		}
+		otel.SetErrorHandler(handler)
+	})
+}
+
+func TestSetGlobal(t *testing.T) {
+	assert := assert.New(t)
+	keepGlobals(t)
+
+	var handled []error
+	tp := sdktrace.NewTracerProvider()
+	tracing := Tracing{
+		tracerProvider: tp,
+		propagator:     XmidtPropagator{},
+		errorHandler:   otel.ErrorHandlerFunc(func(err error) { handled = append(handled, err) }),
+	}
+	previousHandler := otel.ErrorHandlerFunc(func(error) {})
+	otel.SetErrorHandler(previousHandler)
+	previousPropagator := otel.GetTextMapPropagator()
+
+	restore := SetGlobal(tracing)
+	assert.Equal(tp, otel.GetTracerProvider())
+	assert.Equal(XmidtPropagator{}, otel.GetTextMapPropagator())
+	otel.Handle(errors.New("export failed"))
+	assert.Len(handled, 1)
+
+	restore()
+	assert.NotEqual(tp, otel.GetTracerProvider())
+	assert.Equal(previousPropagator, otel.GetTextMapPropagator())
+	otel.Handle(errors.New("export failed"))
+	assert.Len(handled, 1)
+}
+
+func TestSetGlobalWithoutErrorHandler(t *testing.T) {
+	keepGlobals(t)
+
+	var handled int
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) { handled++ }))
+	restore := SetGlobal(Tracing{})
+	defer restore()
+
+	otel.Handle(errors.New("export failed"))
+	assert.Equal(t, 1, handled, "the error handler is left alone when tracing has none")
+	assert.Equal(t, Tracing{}.TracerProvider(), otel.GetTracerProvider())
+}
+
+// Tracing must stay comparable, as downstream code may compare Tracing values
+// or use them as map keys; this fails to compile otherwise.
+var _ map[Tracing]struct{}
+
+func TestNewRegisterGlobal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	keepGlobals(t)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {}))
+
+	var handled int
+	config := Config{
+		Provider:        "stdout",
+		SkipTraceExport: true,
+		ErrorHandler:    otel.ErrorHandlerFunc(func(error) { handled++ }),
+	}
+	tracing, err := New(config)
+	require.NoError(err)
+	assert.NotEqual(tracing.TracerProvider(), otel.GetTracerProvider(), "globals are opt-in")
+	otel.Handle(errors.New("queue full"))
+	assert.Equal(1, handled, "the error handler is installed whenever set")
+	tracing.RestoreGlobal()
+	otel.Handle(errors.New("queue full"))
+	assert.Equal(1, handled)
+
+	config.RegisterGlobal = true
+	tracing, err = New(config)
+	require.NoError(err)
+	assert.Equal(tracing.TracerProvider(), otel.GetTracerProvider())
+	assert.Equal(tracing.Propagator(), otel.GetTextMapPropagator())
+	otel.Handle(errors.New("export failed"))
+	assert.Equal(2, handled)
+
+	tracing.RestoreGlobal()
+	assert.NotEqual(tracing.TracerProvider(), otel.GetTracerProvider())
+	otel.Handle(errors.New("export failed"))
+	assert.Equal(2, handled, "the error handler is put back too")
+	Tracing{}.RestoreGlobal()
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MessageHeader is a Kafka message header. Most Kafka clients use the same
+// shape, so their headers convert to and from MessageHeader directly.
+type MessageHeader struct {
+	Key   string
+	Value []byte
+}
+
+// MessageHeaderCarrier adapts a slice of message headers to the
+// propagation.TextMapCarrier interface. Header keys are case sensitive.
+type MessageHeaderCarrier []MessageHeader
+
+var _ propagation.TextMapCarrier = (*MessageHeaderCarrier)(nil)
+
+// Get returns the value of the last header with the given key, or the empty
+// string if there is none.
+func (c *MessageHeaderCarrier) Get(key string) string {
+	for i := len(*c) - 1; i >= 0; i-- {
+		if (*c)[i].Key == key {
+			return string((*c)[i].Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces the value of the headers with the given key, adding a header
+// if there is none.
+func (c *MessageHeaderCarrier) Set(key, value string) {
+	found := false
+	for i := range *c {
+		if (*c)[i].Key == key {
+			(*c)[i].Value = []byte(value)
+			found = true
+		}
+	}
+	if !found {
+		*c = append(*c, MessageHeader{Key: key, Value: []byte(value)})
+	}
+}
+
+// Keys returns the keys of all the headers.
+func (c *MessageHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c))
+	for _, h := range *c {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+func messagingAttributes(topic string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKey.String(topic),
+		semconv.MessagingDestinationKindTopic,
+	}
+}
+
+// StartProducerSpan starts a PRODUCER span for a message about to be sent to
+// topic and injects the span's context into the message headers, so that
+// consumers can continue the trace. The caller must end the span once the
+// message has been handed to the broker.
+func (t Tracing) StartProducerSpan(ctx context.Context, topic string, headers *[]MessageHeader, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	opts = append([]trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(messagingAttributes(topic)...),
+	}, opts...)
+	ctx, span := t.TracerProvider().Tracer(instrumentationName).Start(ctx, topic+" send", opts...)
+	t.Propagator().Inject(ctx, (*MessageHeaderCarrier)(headers))
+	return ctx, span
+}
+
+// StartConsumerSpan starts a CONSUMER span for a message received from topic.
+// The span links to the producer span found in the message headers. If ctx
+// has no span of its own, as in a typical consume loop, the producer span is
+// also used as the parent so the trace continues across the broker. Baggage
+// found in the headers is added to the returned context.
+func (t Tracing) StartConsumerSpan(ctx context.Context, topic string, headers []MessageHeader, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	carrier := MessageHeaderCarrier(headers)
+	extracted := t.Propagator().Extract(ctx, &carrier)
+	producer := trace.SpanContextFromContext(extracted)
+
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		if b := baggage.FromContext(extracted); b.Len() > 0 {
+			ctx = baggage.ContextWithBaggage(ctx, b)
+		}
+	} else {
+		ctx = extracted
+	}
+
+	opts = append([]trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(messagingAttributes(topic)...),
+		trace.WithAttributes(semconv.MessagingOperationReceive),
+	}, opts...)
+	if producer.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: producer}))
+	}
+	return t.TracerProvider().Tracer(instrumentationName).Start(ctx, topic+" receive", opts...)
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMessageHeaderCarrier(t *testing.T) {
+	assert := assert.New(t)
+
+	carrier := MessageHeaderCarrier{
+		{Key: "content-type", Value: []byte("application/msgpack")},
+		{Key: "traceparent", Value: []byte("old")},
+	}
+	assert.Equal("old", carrier.Get("traceparent"))
+	assert.Empty(carrier.Get("Traceparent"))
+	assert.Empty(carrier.Get("tracestate"))
+
+	carrier.Set("traceparent", "new")
+	carrier.Set("tracestate", "rojo=00f067aa0ba902b7")
+	assert.Equal("new", carrier.Get("traceparent"))
+	assert.Equal("rojo=00f067aa0ba902b7", carrier.Get("tracestate"))
+	assert.Equal([]string{"content-type", "traceparent", "tracestate"}, carrier.Keys())
+}
+
+func newTestTracing(exporter sdktrace.SpanExporter) Tracing {
+	return Tracing{
+		tracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		propagator:     propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+}
+
+func TestProducerConsumerSpans(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracing := newTestTracing(exporter)
+
+	member, err := baggage.NewMember("partner", "comcast")
+	require.NoError(err)
+	bag, err := baggage.New(member)
+	require.NoError(err)
+
+	headers := []MessageHeader{{Key: "content-type", Value: []byte("application/msgpack")}}
+	_, producer := tracing.StartProducerSpan(baggage.ContextWithBaggage(context.Background(), bag), "device-status", &headers)
+	producer.End()
+	require.Len(headers, 3)
+
+	ctx, consumer := tracing.StartConsumerSpan(context.Background(), "device-status", headers)
+	consumer.End()
+	assert.Equal("comcast", baggage.FromContext(ctx).Member("partner").Value())
+
+	spans := exporter.GetSpans()
+	require.Len(spans, 2)
+	p, c := spans[0], spans[1]
+
+	assert.Equal("device-status send", p.Name)
+	assert.Equal(trace.SpanKindProducer, p.SpanKind)
+	assert.Contains(p.Attributes, semconv.MessagingDestinationKey.String("device-status"))
+	assert.Contains(p.Attributes, semconv.MessagingSystemKey.String("kafka"))
+
+	assert.Equal("device-status receive", c.Name)
+	assert.Equal(trace.SpanKindConsumer, c.SpanKind)
+	assert.Contains(c.Attributes, semconv.MessagingOperationReceive)
+	assert.Equal(p.SpanContext.SpanID(), c.Parent.SpanID())
+	assert.Equal(p.SpanContext.TraceID(), c.SpanContext.TraceID())
+	require.Len(c.Links, 1)
+	assert.Equal(p.SpanContext.SpanID(), c.Links[0].SpanContext.SpanID())
+}
+
+func TestConsumerSpanWithExistingParent(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracing := newTestTracing(exporter)
+	tracer := tracing.TracerProvider().Tracer("test")
+
+	var headers []MessageHeader
+	_, producer := tracing.StartProducerSpan(context.Background(), "events", &headers)
+	producer.End()
+
+	ctx, batch := tracer.Start(context.Background(), "poll")
+	_, consumer := tracing.StartConsumerSpan(ctx, "events", headers)
+	consumer.End()
+	batch.End()
+
+	spans := exporter.GetSpans()
+	require.Len(spans, 3)
+	c := spans[1]
+	assert.Equal(batch.SpanContext().SpanID(), c.Parent.SpanID())
+	require.Len(c.Links, 1)
+	assert.Equal(producer.SpanContext().SpanID(), c.Links[0].SpanContext.SpanID())
+}
+
+func TestConsumerSpanWithoutTraceHeaders(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracing := newTestTracing(exporter)
+
+	_, consumer := tracing.StartConsumerSpan(context.Background(), "events", nil)
+	consumer.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.False(t, spans[0].Parent.IsValid())
+	assert.Empty(t, spans[0].Links)
+}
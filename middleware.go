@@ -4,15 +4,21 @@
 package candlelight
 
 import (
+	"bufio"
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"runtime/debug"
 	"strings"
 
 	"github.com/xmidt-org/wrp-go/v3/wrpcontext"
 	"github.com/xmidt-org/wrp-go/v3/wrphttp"
 
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -34,37 +40,100 @@ const (
 // existing traces while tracestate is optional.
 // Deprecated. Please consider using EchoFirstTraceNodeInfo.
 func (traceConfig *TraceConfig) TraceMiddleware(delegate http.Handler) http.Handler {
+	spanName := traceConfig.SpanNameFormatter
+	if spanName == nil {
+		spanName = PathSpanName
+	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if skipTracing(traceConfig.Filters, r) {
+			delegate.ServeHTTP(w, r)
+			return
+		}
 		prop := propagation.TraceContext{}
 		ctx := prop.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 		sc := trace.SpanContextFromContext(ctx)
-		tracer := traceConfig.TraceProvider.Tracer(r.URL.Path)
-		ctx, span := tracer.Start(ctx, r.URL.Path)
+		tracer := traceConfig.TraceProvider.Tracer(instrumentationName)
+		name := spanName(r)
+		ctx, span := tracer.Start(ctx, name)
 		defer span.End()
 		if !sc.IsValid() {
 			w.Header().Set(spanIDHeaderName, span.SpanContext().SpanID().String())
 			w.Header().Set(traceIDHeaderName, span.SpanContext().TraceID().String())
 		}
-		delegate.ServeHTTP(w, r.WithContext(ctx))
+		r = r.WithContext(ctx)
+		delegate.ServeHTTP(w, r)
+		if final := spanName(r); final != name {
+			span.SetName(final)
+		}
 	})
 }
 
+// middlewareConfig holds the optional settings of the tracing middleware.
+type middlewareConfig struct {
+	filters         []RequestFilter
+	headerErrorHook func(error)
+	deviceIDBaggage bool
+}
+
+// MiddlewareOption configures the tracing middleware.
+type MiddlewareOption func(*middlewareConfig)
+
+// WithRequestFilter makes the middleware pass the requests matched by any of
+// filters to the next handler without extracting trace information. The WRP
+// message of a decodable request is still decoded into its context.
+func WithRequestFilter(filters ...RequestFilter) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.filters = append(c.filters, filters...)
+	}
+}
+
+// WithHeaderErrorHook sets a function called with an error wrapping
+// ErrMalformedTraceHeader for each trace header entry that cannot be parsed.
+// Such entries are otherwise ignored.
+func WithHeaderErrorHook(hook func(error)) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.headerErrorHook = hook
+	}
+}
+
+// WithDeviceIDBaggage makes the middleware add the device ID of the WRP
+// message in the request's context, its source or else its destination, to
+// the baggage of the context unless it already has one. The device ID is
+// then propagated to the services called with the context, which see it
+// even if they do not decode the message, e.g. for DeviceSamplingConfig.
+func WithDeviceIDBaggage() MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.deviceIDBaggage = true
+	}
+}
+
 // EchoFirstNodeTraceInfo captures the trace information from a request, writes it
 // back in the response headers, and adds it to the request's context
+// The trace headers of a WRP message may carry baggage, which is added to the
+// context as well.
 // It can also decode the request and save the resulting WRP object in the context if isDecodable is true
-func EchoFirstTraceNodeInfo(tracing Tracing, isDecodable bool) func(http.Handler) http.Handler {
+func EchoFirstTraceNodeInfo(tracing Tracing, isDecodable bool, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	var config middlewareConfig
+	for _, o := range opts {
+		o(&config)
+	}
 	return func(delegate http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-
-			var ctx context.Context
-			headerPrefix := tracing.headerPrefix
-			propagator := tracing.Propagator()
-
 			if isDecodable {
 				if req, err := wrphttp.DecodeRequest(r, nil); err == nil {
 					r = req
 				}
 			}
+			// Filters only skip the trace extraction, the decoded message is
+			// still handed to the delegate.
+			if skipTracing(config.filters, r) {
+				delegate.ServeHTTP(w, r)
+				return
+			}
+
+			var ctx context.Context
+			headerPrefix := tracing.headerPrefix
+			propagator := tracing.Propagator()
 
 			var traceHeaders []string
 			ctx = propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
@@ -74,25 +143,115 @@ func EchoFirstTraceNodeInfo(tracing Tracing, isDecodable bool) func(http.Handler
 				traceHeaders = headers
 			}
 
-			// Iterate through the trace headers (if any), format them, and add them to ctx
+			// Iterate through the trace headers (if any), parse them, and add them to ctx
 			var tmp propagation.TextMapCarrier = propagation.MapCarrier{}
 			for _, f := range traceHeaders {
-				if f != "" {
-					parts := strings.Split(f, ":")
-					if len(parts) > 1 {
-						// Remove leading space if there's any
-						parts[1] = strings.Trim(parts[1], " ")
-						tmp.Set(parts[0], parts[1])
+				if f == "" {
+					continue
+				}
+				key, value, err := ParseTraceHeader(f)
+				if err != nil {
+					if config.headerErrorHook != nil {
+						config.headerErrorHook(err)
 					}
+					continue
 				}
+				tmp.Set(key, value)
 			}
 
-			ctx = propagation.TraceContext{}.Extract(ctx, tmp)
+			ctx = defaultPropagator().Extract(ctx, tmp)
+			if config.deviceIDBaggage {
+				ctx = withDeviceIDBaggage(ctx)
+			}
 			delegate.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// RecoverPanic recovers panics from the delegate handler and records them on
+// the span found in the request's context as an exception event with a stack
+// trace, marking the span as failed. A 500 is written if the delegate has not
+// written a response yet. If repanic is true the panic is propagated after
+// being recorded, so that outer handlers or the server still see it.
+// Panics with http.ErrAbortHandler are always propagated untouched.
+//
+// It must be installed under a middleware starting a recording span for the
+// request, such as TraceMiddleware. EchoFirstTraceNodeInfo only extracts the
+// trace context and starts no span, so under it alone panics are recovered
+// but not recorded.
+func RecoverPanic(repanic bool) func(http.Handler) http.Handler {
+	return func(delegate http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := &headerTracker{ResponseWriter: w}
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if err, ok := p.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(p)
+				}
+
+				span := trace.SpanFromContext(r.Context())
+				recordPanic(span, p, debug.Stack())
+				span.SetStatus(codes.Error, fmt.Sprintf("panic: %v", p))
+				if !rw.wroteHeader {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+				if repanic {
+					panic(p)
+				}
+			}()
+			delegate.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// headerTracker remembers whether a response has been started.
+type headerTracker struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (t *headerTracker) WriteHeader(code int) {
+	t.wroteHeader = true
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *headerTracker) Write(b []byte) (int, error) {
+	t.wroteHeader = true
+	return t.ResponseWriter.Write(b)
+}
+
+// Unwrap gives http.ResponseController access to the original writer.
+func (t *headerTracker) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+// Flush forwards to the original writer, so that streaming handlers still
+// find an http.Flusher.
+func (t *headerTracker) Flush() {
+	if f, ok := t.ResponseWriter.(http.Flusher); ok {
+		t.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack forwards to the original writer, so that websocket handlers still
+// find an http.Hijacker.
+func (t *headerTracker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := t.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %T is not an http.Hijacker", http.ErrNotSupported, t.ResponseWriter)
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		// The connection is no longer ours to write a 500 to.
+		t.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
 // GenTID generates a 16-byte long string
 // it returns "N/A" in the extreme case the random string could not be generated
 func GenTID() (tid string) {
@@ -103,3 +262,35 @@ func GenTID() (tid string) {
 	}
 	return
 }
+
+// derivedTIDPrefix marks the transaction IDs generated by GenTIDFromContext,
+// so that they are not mistaken for client supplied IDs that happen to look
+// like a trace ID.
+const derivedTIDPrefix = "trace-"
+
+// GenTIDFromContext generates a transaction ID derived from the trace ID of the
+// span in ctx, so that transaction and trace IDs match: the transaction ID is
+// the trace ID in its 32 character hex form, prefixed with "trace-". If ctx
+// has no valid span, a random ID is generated as by GenTID.
+func GenTIDFromContext(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.TraceID().IsValid() {
+		return derivedTIDPrefix + sc.TraceID().String()
+	}
+	return GenTID()
+}
+
+// TraceIDFromTID returns the trace ID a transaction ID was derived from by
+// GenTIDFromContext, which can be used to look up the transaction's trace.
+// The boolean is false for other transaction IDs, such as the random ones
+// generated by GenTID or bare trace IDs sent by clients.
+func TraceIDFromTID(tid string) (trace.TraceID, bool) {
+	hex, ok := strings.CutPrefix(tid, derivedTIDPrefix)
+	if !ok {
+		return trace.TraceID{}, false
+	}
+	traceID, err := trace.TraceIDFromHex(hex)
+	if err != nil {
+		return trace.TraceID{}, false
+	}
+	return traceID, true
+}
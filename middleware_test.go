@@ -4,13 +4,185 @@
 package candlelight
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestGenTID(t *testing.T) {
 	assert := assert.New(t)
 	tid := GenTID()
 	assert.NotEmpty(tid)
+	_, ok := TraceIDFromTID(tid)
+	assert.False(ok)
+}
+
+func TestGenTIDFromContext(t *testing.T) {
+	assert := assert.New(t)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	tid := GenTIDFromContext(ctx)
+	assert.Equal("trace-4bf92f3577b34da6a3ce929d0e0e4736", tid)
+	derived, ok := TraceIDFromTID(tid)
+	assert.True(ok)
+	assert.Equal(traceID, derived)
+
+	tid = GenTIDFromContext(context.Background())
+	assert.NotEmpty(tid)
+	_, ok = TraceIDFromTID(tid)
+	assert.False(ok)
+}
+
+func TestTraceIDFromTID(t *testing.T) {
+	for _, tid := range []string{
+		"", "N/A", "trace-", "trace-00000000000000000000000000000000", "trace-4BF92F3577B34DA6A3CE929D0E0E4736",
+		"trace-4bf92f3577b34da6a3ce929d0e0e47",
+		// A client supplied TID looking like a trace ID is not derived.
+		"4bf92f3577b34da6a3ce929d0e0e4736",
+	} {
+		_, ok := TraceIDFromTID(tid)
+		assert.False(t, ok, tid)
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Repanic     bool
+		Handler     http.HandlerFunc
+		Status      int
+		Panics      bool
+		Recorded    bool
+	}{
+		{
+			Description: "No panic",
+			Handler:     func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusAccepted) },
+			Status:      http.StatusAccepted,
+		},
+		{
+			Description: "Panic recovered",
+			Handler:     func(http.ResponseWriter, *http.Request) { panic("boom") },
+			Status:      http.StatusInternalServerError,
+			Recorded:    true,
+		},
+		{
+			Description: "Panic after response started",
+			Handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				panic("boom")
+			},
+			Status:   http.StatusOK,
+			Recorded: true,
+		},
+		{
+			Description: "Repanic",
+			Repanic:     true,
+			Handler:     func(http.ResponseWriter, *http.Request) { panic("boom") },
+			Status:      http.StatusInternalServerError,
+			Panics:      true,
+			Recorded:    true,
+		},
+		{
+			Description: "Abort handler",
+			Handler:     func(http.ResponseWriter, *http.Request) { panic(http.ErrAbortHandler) },
+			Status:      http.StatusOK,
+			Panics:      true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			exporter := tracetest.NewInMemoryExporter()
+			tracing := newTestTracing(exporter)
+			ctx, span := tracing.TracerProvider().Tracer("test").Start(context.Background(), "request")
+
+			handler := RecoverPanic(tc.Repanic)(tc.Handler)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+			serve := func() { handler.ServeHTTP(rec, req) }
+			if tc.Panics {
+				assert.Panics(serve)
+			} else {
+				assert.NotPanics(serve)
+			}
+			span.End()
+
+			assert.Equal(tc.Status, rec.Code)
+			spans := exporter.GetSpans()
+			require.Len(spans, 1)
+			if !tc.Recorded {
+				assert.Equal(codes.Unset, spans[0].Status.Code)
+				assert.Empty(spans[0].Events)
+				return
+			}
+			assert.Equal(codes.Error, spans[0].Status.Code)
+			require.Len(spans[0].Events, 1)
+			event := spans[0].Events[0]
+			assert.Equal(semconv.ExceptionEventName, event.Name)
+			assert.Contains(event.Attributes, semconv.ExceptionMessageKey.String("boom"))
+			assert.Contains(event.Attributes, semconv.ExceptionTypeKey.String("string"))
+		})
+	}
+}
+
+func TestRecoverPanicFlush(t *testing.T) {
+	handler := RecoverPanic(false)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		f, ok := w.(http.Flusher)
+		require.True(t, ok)
+		f.Flush()
+		panic("boom")
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, http.StatusOK, rec.Code, "the flushed response is not overwritten")
+}
+
+func TestRecoverPanicHijack(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	server := httptest.NewServer(RecoverPanic(false)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		h, ok := w.(http.Hijacker)
+		if !assert.True(ok) {
+			return
+		}
+		conn, rw, err := h.Hijack()
+		if !assert.NoError(err) {
+			return
+		}
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: close\r\n\r\n")
+		_ = rw.Flush()
+	})))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(err)
+	defer resp.Body.Close()
+	assert.Equal(http.StatusSwitchingProtocols, resp.StatusCode)
+
+	// Writers that cannot be hijacked report it.
+	handler := RecoverPanic(false)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _, err := w.(http.Hijacker).Hijack()
+		assert.ErrorIs(err, http.ErrNotSupported)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
 }
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures New with settings that cannot be expressed in a Config
+// file.
+type Option func(*options)
+
+// options holds the settings given to New. They reach the provider
+// constructors through Config.
+type options struct {
+	processors  []sdktrace.SpanProcessor
+	resources   []*resource.Resource
+	sampler     sdktrace.Sampler
+	propagator  propagation.TextMapPropagator
+	exporter    sdktrace.SpanExporter
+	idGenerator sdktrace.IDGenerator
+
+	errorHandler otel.ErrorHandler
+	reporter     *errorReporter
+}
+
+// WithSpanProcessor registers processors on the tracer provider, after the
+// ones set up from the Config. Like those, they are only registered when the
+// provider is an OpenTelemetry SDK TracerProvider.
+func WithSpanProcessor(processors ...sdktrace.SpanProcessor) Option {
+	return func(o *options) {
+		o.processors = append(o.processors, processors...)
+	}
+}
+
+// WithResource merges r into the resource describing the service, which
+// holds the service name taken from Config.ApplicationName. Attributes of r
+// take precedence. It applies to the built-in providers and to WithExporter.
+func WithResource(r *resource.Resource) Option {
+	return func(o *options) {
+		o.resources = append(o.resources, r)
+	}
+}
+
+// WithSampler uses sampler in place of the one derived from the parentBased
+// and noParent settings. Config.DeviceSampling still applies on top of it.
+// Every built-in provider uses the sampler; custom providers receive it as
+// the sampler argument of their ProviderConstructor.
+func WithSampler(sampler sdktrace.Sampler) Option {
+	return func(o *options) {
+		o.sampler = sampler
+	}
+}
+
+// WithPropagator uses propagator in place of the default W3C Trace Context
+// and Baggage propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		o.propagator = propagator
+	}
+}
+
+// WithExporter exports spans to exporter instead of building a provider from
+// Config.Provider, which is then ignored along with the exporter settings of
+// the Config. The span processing settings, such as redaction, retries and
+// tail sampling, still apply, and spans are exported in batches.
+func WithExporter(exporter sdktrace.SpanExporter) Option {
+	return func(o *options) {
+		o.exporter = exporter
+	}
+}
+
+// WithIDGenerator makes the built-in providers, and WithExporter, create
+// trace and span IDs with generator.
+func WithIDGenerator(generator sdktrace.IDGenerator) Option {
+	return func(o *options) {
+		o.idGenerator = generator
+	}
+}
+
+// newSDKTracerProvider builds the tracer provider of a built-in provider,
+// described by res, adding the settings given to New to opts. A nil res
+// leaves the SDK default resource unless resources were given to New.
+func newSDKTracerProvider(cfg Config, res *resource.Resource, opts ...sdktrace.TracerProviderOption) (trace.TracerProvider, error) {
+	if len(cfg.options.resources) > 0 && res == nil {
+		res = resource.Default()
+	}
+	for _, r := range cfg.options.resources {
+		merged, err := resource.Merge(res, r)
+		if errors.Is(err, resource.ErrSchemaURLConflict) {
+			// Keep the schema of the built-in resource.
+			merged, err = resource.Merge(res, resource.NewSchemaless(r.Attributes()...))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+		}
+		res = merged
+	}
+	if res != nil {
+		opts = append(opts, sdktrace.WithResource(res))
+	}
+	if cfg.options.idGenerator != nil {
+		opts = append(opts, sdktrace.WithIDGenerator(cfg.options.idGenerator))
+	}
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+// exporterProvider builds the tracer provider exporting to the exporter
+// given with WithExporter.
+func exporterProvider(cfg Config, smplr sdktrace.Sampler) (trace.TracerProvider, error) {
+	processor, err := exportSpanProcessor(cfg, cfg.options.exporter, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+	}
+	return newSDKTracerProvider(cfg,
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(cfg.ApplicationName),
+		),
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithRawSpanLimits(cfg.SpanLimits.sdkSpanLimits()),
+		sdktrace.WithSampler(smplr),
+	)
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/candlelight/collectortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fixedIDGenerator hands out the same IDs for every span.
+type fixedIDGenerator struct{}
+
+func (fixedIDGenerator) NewIDs(context.Context) (trace.TraceID, trace.SpanID) {
+	return trace.TraceID{0xca, 0xfe}, trace.SpanID{0xbe, 0xef}
+}
+
+func (fixedIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	return trace.SpanID{0xbe, 0xef}
+}
+
+func TestNewWithExporter(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	recorder := tracetest.NewSpanRecorder()
+	tracing, err := New(
+		Config{
+			ApplicationName: "candlelight-test",
+			Provider:        "ignored",
+			ParentBased:     "honor",
+			NoParent:        "always",
+			Redactions:      []RedactionRule{{Key: "password", Action: RedactDrop}},
+		},
+		WithExporter(exporter),
+		WithSpanProcessor(recorder),
+		WithResource(resource.NewWithAttributes("https://opentelemetry.io/schemas/1.26.0", attribute.String("deployment.environment", "test"))),
+		WithIDGenerator(fixedIDGenerator{}),
+		WithPropagator(XmidtPropagator{}),
+	)
+	require.NoError(err)
+	assert.Equal(XmidtPropagator{}, tracing.Propagator())
+
+	_, span := tracing.TracerProvider().Tracer("test").Start(context.Background(), "span",
+		trace.WithAttributes(attribute.String("password", "secret")))
+	span.End()
+	tp, ok := tracing.TracerProvider().(*sdktrace.TracerProvider)
+	require.True(ok)
+	require.NoError(tp.ForceFlush(context.Background()))
+
+	spans := exporter.GetSpans()
+	require.Len(spans, 1)
+	assert.Equal(trace.TraceID{0xca, 0xfe}, spans[0].SpanContext.TraceID())
+	assert.Empty(spans[0].Attributes, "the config span processing applies")
+	assert.ElementsMatch([]attribute.KeyValue{
+		attribute.String("service.name", "candlelight-test"),
+		attribute.String("deployment.environment", "test"),
+	}, spans[0].Resource.Attributes())
+	assert.Len(recorder.Ended(), 1)
+}
+
+func TestNewWithSampler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	config := Config{
+		ParentBased:    "honor",
+		NoParent:       "always",
+		DeviceSampling: DeviceSamplingConfig{Devices: []string{"mac:112233445566"}},
+	}
+	tracing, err := New(config, WithExporter(exporter), WithSampler(sdktrace.NeverSample()))
+	require.NoError(err)
+
+	tracer := tracing.TracerProvider().Tracer("test")
+	_, span := tracer.Start(context.Background(), "dropped")
+	assert.False(span.SpanContext().IsSampled())
+	_, span = tracer.Start(wrpContext("mac:112233445566", ""), "device")
+	assert.True(span.SpanContext().IsSampled(), "device sampling applies on top of the sampler")
+}
+
+func TestNewOptionsBuiltInProviders(t *testing.T) {
+	collector, err := collectortest.New()
+	require.NoError(t, err)
+	defer collector.Close()
+
+	tcs := []Config{
+		{Provider: "otlp/grpc", Endpoint: collector.GRPCEndpoint},
+		{Provider: "otlp/http", Endpoint: collector.HTTPEndpoint},
+		{Provider: "jaeger", Endpoint: "http://localhost:14268/api/traces"},
+		{Provider: "zipkin", Endpoint: "http://localhost:9411/api/v2/spans"},
+		{Provider: "stdout", SkipTraceExport: true},
+	}
+	for _, config := range tcs {
+		t.Run(config.Provider, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			recorder := tracetest.NewSpanRecorder()
+			tracing, err := New(config,
+				WithSpanProcessor(recorder),
+				WithResource(resource.NewSchemaless(attribute.String("deployment.environment", "test"))),
+				WithIDGenerator(fixedIDGenerator{}),
+				WithSampler(sdktrace.AlwaysSample()),
+			)
+			require.NoError(err)
+			tp, ok := tracing.TracerProvider().(*sdktrace.TracerProvider)
+			require.True(ok)
+			defer func() {
+				// Nothing listens on the jaeger and zipkin endpoints.
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_ = tp.Shutdown(ctx)
+			}()
+
+			_, span := tracing.TracerProvider().Tracer("test").Start(context.Background(), "span")
+			span.End()
+
+			ended := recorder.Ended()
+			require.Len(ended, 1, "the sampler replaces parentBased ignore")
+			assert.Equal(trace.SpanID{0xbe, 0xef}, ended[0].SpanContext().SpanID())
+			value, ok := ended[0].Resource().Set().Value("deployment.environment")
+			assert.True(ok)
+			assert.Equal("test", value.AsString())
+			_, ok = ended[0].Resource().Set().Value("service.name")
+			assert.True(ok)
+
+			config.ParentBased = "honor"
+			config.NoParent = "always"
+			never, err := New(config, WithSampler(sdktrace.NeverSample()))
+			require.NoError(err)
+			defer func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_ = never.TracerProvider().(*sdktrace.TracerProvider).Shutdown(ctx)
+			}()
+			_, span = never.TracerProvider().Tracer("test").Start(context.Background(), "span")
+			assert.False(span.SpanContext().IsSampled(), "the sampler replaces noParent always")
+		})
+	}
+}
+
+func TestNewWithSpanProcessorNoop(t *testing.T) {
+	tracing, err := New(Config{}, WithSpanProcessor(tracetest.NewSpanRecorder()))
+	require.NoError(t, err)
+	assert.True(t, tracing.IsNoop())
+}
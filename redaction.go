@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Redaction actions supported by RedactionRule.
+const (
+	RedactDrop = "drop"
+	RedactHash = "hash"
+	RedactMask = "mask"
+)
+
+// redactionMask replaces masked values.
+const redactionMask = "****"
+
+var (
+	ErrInvalidRedactionAction  = errors.New("invalid redaction action")
+	ErrInvalidRedactionPattern = errors.New("invalid redaction pattern")
+)
+
+// RedactionRule describes attributes that must not be exported as is.
+// An attribute is matched when its key matches Key and its value matches
+// Value. At least one of Key and Value must be set.
+type RedactionRule struct {
+	// Key is a glob pattern, as understood by path.Match, matched against
+	// attribute keys, e.g. "wrp.*". An empty Key matches every attribute.
+	Key string `json:"key"`
+
+	// Value is a regular expression matched against the string form of
+	// attribute values. An empty Value matches every value.
+	Value string `json:"value"`
+
+	// Action is what happens to a matched attribute:
+	// Action = "drop", the attribute is removed
+	// Action = "hash", the value is replaced by its hex encoded HMAC-SHA256
+	// keyed with HashKey, or by its plain SHA-256 digest if HashKey is empty
+	// Action = "mask", the parts of the value matching Value, or the whole
+	// value if Value is empty, are replaced with "****"
+	// Hashed and masked values are strings, whatever the type of the original
+	// value: an int or bool attribute becomes a string attribute, and the
+	// elements of a string slice are redacted one by one.
+	Action string `json:"action"`
+
+	// HashKey is the secret key of the "hash" action. Without it, hashing is
+	// only a linkable pseudonymisation: values from a small space, such as
+	// MAC addresses, are recovered by hashing every candidate. Set a key,
+	// kept out of the exported data, whenever hashed values must not be
+	// reversible.
+	HashKey string `json:"hashKey"`
+}
+
+type compiledRedactionRule struct {
+	key     string
+	value   *regexp.Regexp
+	action  string
+	hashKey []byte
+}
+
+// redactor applies a list of redaction rules to attributes.
+type redactor []compiledRedactionRule
+
+// newRedactor compiles rules, returning a nil redactor if there are none.
+func newRedactor(rules []RedactionRule) (redactor, error) {
+	r, errs := compileRedactionRules(rules)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(r) == 0 {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// compileRedactionRules compiles rules, returning the problems found with
+// each of them.
+func compileRedactionRules(rules []RedactionRule) (redactor, []error) {
+	var errs []error
+	r := make(redactor, 0, len(rules))
+	for i, rule := range rules {
+		compiled, err := compileRedactionRule(rule)
+		if err != nil {
+			errs = append(errs, &FieldError{Field: fmt.Sprintf("redactions[%d]", i), Err: err})
+			continue
+		}
+		r = append(r, compiled)
+	}
+	return r, errs
+}
+
+func compileRedactionRule(rule RedactionRule) (compiledRedactionRule, error) {
+	compiled := compiledRedactionRule{key: rule.Key, action: rule.Action}
+	if rule.HashKey != "" {
+		compiled.hashKey = []byte(rule.HashKey)
+	}
+	switch rule.Action {
+	case RedactDrop, RedactHash, RedactMask:
+	default:
+		return compiled, fmt.Errorf("%w: %q", ErrInvalidRedactionAction, rule.Action)
+	}
+	if rule.Key == "" && rule.Value == "" {
+		return compiled, fmt.Errorf("%w: one of key or value is required", ErrInvalidRedactionPattern)
+	}
+	if _, err := path.Match(rule.Key, ""); err != nil {
+		return compiled, fmt.Errorf("%w: key %q: %v", ErrInvalidRedactionPattern, rule.Key, err)
+	}
+	if rule.Value != "" {
+		value, err := regexp.Compile(rule.Value)
+		if err != nil {
+			return compiled, fmt.Errorf("%w: value %q: %v", ErrInvalidRedactionPattern, rule.Value, err)
+		}
+		compiled.value = value
+	}
+	return compiled, nil
+}
+
+// redact returns attrs with the rules applied. The original slice is never
+// modified.
+func (r redactor) redact(attrs []attribute.KeyValue) []attribute.KeyValue {
+	result := make([]attribute.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		if kv, keep := r.redactAttribute(kv); keep {
+			result = append(result, kv)
+		}
+	}
+	return result
+}
+
+func (r redactor) redactAttribute(kv attribute.KeyValue) (attribute.KeyValue, bool) {
+	for _, rule := range r {
+		if rule.key != "" {
+			if ok, _ := path.Match(rule.key, string(kv.Key)); !ok {
+				continue
+			}
+		}
+		if kv.Value.Type() == attribute.STRINGSLICE {
+			values := kv.Value.AsStringSlice()
+			matched := false
+			for i, v := range values {
+				if rule.matches(v) {
+					values[i] = rule.apply(v)
+					matched = true
+				}
+			}
+			if !matched {
+				continue
+			}
+			if rule.action == RedactDrop {
+				return kv, false
+			}
+			kv = kv.Key.StringSlice(values)
+			continue
+		}
+
+		v := kv.Value.Emit()
+		if !rule.matches(v) {
+			continue
+		}
+		if rule.action == RedactDrop {
+			return kv, false
+		}
+		kv = kv.Key.String(rule.apply(v))
+	}
+	return kv, true
+}
+
+func (rule compiledRedactionRule) matches(v string) bool {
+	return rule.value == nil || rule.value.MatchString(v)
+}
+
+func (rule compiledRedactionRule) apply(v string) string {
+	switch rule.action {
+	case RedactHash:
+		if rule.hashKey != nil {
+			mac := hmac.New(sha256.New, rule.hashKey)
+			mac.Write([]byte(v))
+			return hex.EncodeToString(mac.Sum(nil))
+		}
+		sum := sha256.Sum256([]byte(v))
+		return hex.EncodeToString(sum[:])
+	case RedactMask:
+		if rule.value == nil {
+			return redactionMask
+		}
+		return rule.value.ReplaceAllLiteralString(v, redactionMask)
+	}
+	return v
+}
+
+// redactionProcessor redacts spans before handing them to the next processor.
+type redactionProcessor struct {
+	redactor redactor
+	next     sdktrace.SpanProcessor
+}
+
+// NewRedactionProcessor returns a span processor that applies rules to the
+// span, event and link attributes of every ended span before passing it on to
+// next, which is typically the processor feeding an exporter. The built-in
+// providers install it automatically when Config.Redactions is set.
+//
+// Resource attributes are not redacted: they are set by the application
+// rather than derived from requests, and are shared by every span.
+func NewRedactionProcessor(rules []RedactionRule, next sdktrace.SpanProcessor) (sdktrace.SpanProcessor, error) {
+	r, err := newRedactor(rules)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return next, nil
+	}
+	return &redactionProcessor{redactor: r, next: next}, nil
+}
+
+func (p *redactionProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+func (p *redactionProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	events := s.Events()
+	redactedEvents := make([]sdktrace.Event, len(events))
+	for i, e := range events {
+		e.Attributes = p.redactor.redact(e.Attributes)
+		redactedEvents[i] = e
+	}
+	links := s.Links()
+	redactedLinks := make([]sdktrace.Link, len(links))
+	for i, l := range links {
+		l.Attributes = p.redactor.redact(l.Attributes)
+		redactedLinks[i] = l
+	}
+	p.next.OnEnd(redactedSpan{
+		ReadOnlySpan: s,
+		attributes:   p.redactor.redact(s.Attributes()),
+		events:       redactedEvents,
+		links:        redactedLinks,
+	})
+}
+
+func (p *redactionProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *redactionProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// redactedSpan overrides the attributes, events and links of a span.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+	attributes []attribute.KeyValue
+	events     []sdktrace.Event
+	links      []sdktrace.Link
+}
+
+func (s redactedSpan) Attributes() []attribute.KeyValue {
+	return s.attributes
+}
+
+func (s redactedSpan) Events() []sdktrace.Event {
+	return s.events
+}
+
+func (s redactedSpan) Links() []sdktrace.Link {
+	return s.links
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRedactor(t *testing.T) {
+	mac := "mac:112233445566"
+	sum := sha256.Sum256([]byte(mac))
+	keyed := hmac.New(sha256.New, []byte("secret"))
+	keyed.Write([]byte(mac))
+
+	tcs := []struct {
+		Description string
+		Rules       []RedactionRule
+		Attributes  []attribute.KeyValue
+		Expected    []attribute.KeyValue
+	}{
+		{
+			Description: "Drop by key",
+			Rules:       []RedactionRule{{Key: "wrp.source", Action: RedactDrop}},
+			Attributes:  []attribute.KeyValue{attribute.String("wrp.source", mac), attribute.Int("status", 200)},
+			Expected:    []attribute.KeyValue{attribute.Int("status", 200)},
+		},
+		{
+			Description: "Hash by key glob",
+			Rules:       []RedactionRule{{Key: "wrp.*", Action: RedactHash}},
+			Attributes:  []attribute.KeyValue{attribute.String("wrp.destination", mac)},
+			Expected:    []attribute.KeyValue{attribute.String("wrp.destination", hex.EncodeToString(sum[:]))},
+		},
+		{
+			Description: "Hash with key",
+			Rules:       []RedactionRule{{Key: "wrp.*", Action: RedactHash, HashKey: "secret"}},
+			Attributes:  []attribute.KeyValue{attribute.String("wrp.destination", mac)},
+			Expected:    []attribute.KeyValue{attribute.String("wrp.destination", hex.EncodeToString(keyed.Sum(nil)))},
+		},
+		{
+			Description: "Non-string values become strings",
+			Rules:       []RedactionRule{{Key: "status", Action: RedactMask}},
+			Attributes:  []attribute.KeyValue{attribute.Int("status", 200)},
+			Expected:    []attribute.KeyValue{attribute.String("status", "****")},
+		},
+		{
+			Description: "Mask by value regex",
+			Rules:       []RedactionRule{{Value: `[0-9a-fA-F]{12}`, Action: RedactMask}},
+			Attributes: []attribute.KeyValue{
+				attribute.String("http.target", "/api/v2/device/mac:112233445566/stat"),
+				attribute.String("http.method", "GET"),
+			},
+			Expected: []attribute.KeyValue{
+				attribute.String("http.target", "/api/v2/device/mac:****/stat"),
+				attribute.String("http.method", "GET"),
+			},
+		},
+		{
+			Description: "Mask whole value",
+			Rules:       []RedactionRule{{Key: "partner", Action: RedactMask}},
+			Attributes:  []attribute.KeyValue{attribute.String("partner", "comcast")},
+			Expected:    []attribute.KeyValue{attribute.String("partner", "****")},
+		},
+		{
+			Description: "Key and value must both match",
+			Rules:       []RedactionRule{{Key: "wrp.source", Value: "^mac:", Action: RedactDrop}},
+			Attributes:  []attribute.KeyValue{attribute.String("wrp.source", "dns:talaria"), attribute.String("other", mac)},
+			Expected:    []attribute.KeyValue{attribute.String("wrp.source", "dns:talaria"), attribute.String("other", mac)},
+		},
+		{
+			Description: "String slices",
+			Rules:       []RedactionRule{{Value: "^mac:", Action: RedactMask}},
+			Attributes:  []attribute.KeyValue{attribute.StringSlice("devices", []string{mac, "dns:talaria"})},
+			Expected:    []attribute.KeyValue{attribute.StringSlice("devices", []string{"****112233445566", "dns:talaria"})},
+		},
+		{
+			Description: "Rules apply in order",
+			Rules: []RedactionRule{
+				{Key: "device", Value: `[0-9a-f]{12}`, Action: RedactMask},
+				{Key: "device", Value: `^mac:\*+$`, Action: RedactDrop},
+			},
+			Attributes: []attribute.KeyValue{attribute.String("device", mac)},
+			Expected:   []attribute.KeyValue{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			r, err := newRedactor(tc.Rules)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, r.redact(tc.Attributes))
+		})
+	}
+}
+
+func TestNewRedactorInvalid(t *testing.T) {
+	_, err := newRedactor([]RedactionRule{
+		{Key: "ok", Action: RedactDrop},
+		{Key: "ok", Action: "erase"},
+		{Action: RedactHash},
+		{Key: "[", Action: RedactHash},
+		{Value: "(", Action: RedactMask},
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidRedactionAction)
+	assert.ErrorIs(t, err, ErrInvalidRedactionPattern)
+	assert.Contains(t, err.Error(), "redactions[1]")
+	assert.Contains(t, err.Error(), "redactions[4]")
+	assert.NotContains(t, err.Error(), "redactions[0]")
+
+	r, err := newRedactor(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestRedactionProcessor(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	processor, err := NewRedactionProcessor(
+		[]RedactionRule{{Key: "wrp.*", Action: RedactDrop}},
+		sdktrace.NewSimpleSpanProcessor(exporter),
+	)
+	require.NoError(err)
+	res := resource.NewSchemaless(attribute.String("wrp.service", "talaria"))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor), sdktrace.WithResource(res))
+
+	link := trace.Link{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x02},
+		}),
+		Attributes: []attribute.KeyValue{attribute.String("wrp.source", "mac:112233445566"), attribute.String("keep", "link")},
+	}
+	_, span := tp.Tracer("test").Start(context.Background(), "span",
+		trace.WithAttributes(attribute.String("wrp.source", "mac:112233445566"), attribute.String("keep", "me")),
+		trace.WithLinks(link))
+	span.AddEvent("event", trace.WithAttributes(attribute.String("wrp.destination", "mac:112233445566")))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(spans, 1)
+	assert.Equal([]attribute.KeyValue{attribute.String("keep", "me")}, spans[0].Attributes)
+	require.Len(spans[0].Events, 1)
+	assert.Empty(spans[0].Events[0].Attributes)
+	require.Len(spans[0].Links, 1)
+	assert.Equal([]attribute.KeyValue{attribute.String("keep", "link")}, spans[0].Links[0].Attributes)
+
+	// Resource attributes are left as they are.
+	assert.Equal(res, spans[0].Resource)
+}
+
+func TestNewRedactionProcessorNoRules(t *testing.T) {
+	next := sdktrace.NewSimpleSpanProcessor(tracetest.NewInMemoryExporter())
+	processor, err := NewRedactionProcessor(nil, next)
+	require.NoError(t, err)
+	assert.Equal(t, next, processor)
+
+	_, err = NewRedactionProcessor([]RedactionRule{{Action: RedactDrop}}, next)
+	assert.ErrorIs(t, err, ErrInvalidRedactionPattern)
+}
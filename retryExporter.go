@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Retry defaults used for the zero values of RetryConfig.
+const (
+	DefaultRetryInitialInterval = time.Second
+	DefaultRetryMaxInterval     = 5 * time.Second
+	DefaultRetryMaxElapsedTime  = 20 * time.Second
+	DefaultSpoolMaxBytes        = 64 << 20
+)
+
+var (
+	ErrInvalidRetryValue = errors.New("invalid retry value provided in configuration")
+	ErrSpansSpooled      = errors.New("export failed, spans spooled for replay")
+)
+
+// RetryConfig configures retrying failed exports with exponential backoff
+// and, optionally, spooling the spans to disk when retries are exhausted so
+// they can be replayed once the endpoint recovers. When enabled, the built-in
+// retries of the OTLP exporters are turned off so that attempts do not
+// multiply.
+type RetryConfig struct {
+	// Enabled turns on retries and spooling for the built-in providers.
+	Enabled bool `json:"enabled"`
+
+	// InitialInterval is the wait before the first retry. It doubles after
+	// each failed attempt. Defaults to 1s.
+	InitialInterval time.Duration `json:"initialInterval"`
+
+	// MaxInterval caps the wait between retries. Defaults to 5s.
+	MaxInterval time.Duration `json:"maxInterval"`
+
+	// MaxElapsedTime is the time after which a batch is no longer retried.
+	// Defaults to 20s, within the 30s the batch span processor allows for an
+	// export.
+	MaxElapsedTime time.Duration `json:"maxElapsedTime"`
+
+	// SpoolDirectory, if set, is where batches that could not be exported are
+	// kept until the endpoint recovers. Spooled batches are replayed, oldest
+	// first, before the next export.
+	SpoolDirectory string `json:"spoolDirectory"`
+
+	// SpoolMaxBytes bounds the size of the spool. The oldest batches are
+	// discarded to make room for new ones. Defaults to 64MiB.
+	SpoolMaxBytes int64 `json:"spoolMaxBytes"`
+}
+
+// validate reports the invalid fields of the retry configuration.
+func (r RetryConfig) validate() []error {
+	var errs []error
+	check := func(field string, invalid bool, reason string) {
+		if invalid {
+			errs = append(errs, &FieldError{
+				Field: "retry." + field,
+				Err:   fmt.Errorf("%w: %s", ErrInvalidRetryValue, reason),
+			})
+		}
+	}
+	check("initialInterval", r.InitialInterval < 0, "negative duration")
+	check("maxInterval", r.MaxInterval < 0, "negative duration")
+	check("maxInterval", r.MaxInterval > 0 && r.MaxInterval < r.InitialInterval, "shorter than initialInterval")
+	check("maxElapsedTime", r.MaxElapsedTime < 0, "negative duration")
+	check("spoolMaxBytes", r.SpoolMaxBytes < 0, "negative size")
+	return errs
+}
+
+func (r RetryConfig) withDefaults() RetryConfig {
+	if r.InitialInterval == 0 {
+		r.InitialInterval = DefaultRetryInitialInterval
+	}
+	if r.MaxInterval == 0 {
+		r.MaxInterval = max(DefaultRetryMaxInterval, r.InitialInterval)
+	}
+	if r.MaxElapsedTime == 0 {
+		r.MaxElapsedTime = DefaultRetryMaxElapsedTime
+	}
+	if r.SpoolMaxBytes == 0 {
+		r.SpoolMaxBytes = DefaultSpoolMaxBytes
+	}
+	return r
+}
+
+// retryExporter retries failed exports and spools what could not be exported.
+type retryExporter struct {
+	next   sdktrace.SpanExporter
+	config RetryConfig
+	spool  *spool
+}
+
+// NewRetryExporter wraps next so that failed exports are retried with
+// exponential backoff, as described by config. The Enabled field is ignored.
+// When a spool directory is configured, batches that still fail are written
+// to disk and replayed ahead of later batches once exports succeed again.
+func NewRetryExporter(next sdktrace.SpanExporter, config RetryConfig) (sdktrace.SpanExporter, error) {
+	if err := errors.Join(config.validate()...); err != nil {
+		return nil, err
+	}
+	e := &retryExporter{next: next, config: config.withDefaults()}
+	if config.SpoolDirectory != "" {
+		s, err := newSpool(config.SpoolDirectory, e.config.SpoolMaxBytes)
+		if err != nil {
+			return nil, err
+		}
+		e.spool = s
+	}
+	return e, nil
+}
+
+func (e *retryExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if e.spool != nil {
+		if err := e.spool.replay(ctx, e.next.ExportSpans); err != nil {
+			// The endpoint is still unavailable, so queue this batch behind
+			// the spooled ones without waiting on retries.
+			return e.spoolSpans(spans, err)
+		}
+	}
+
+	err := e.export(ctx, spans)
+	if err != nil && e.spool != nil {
+		return e.spoolSpans(spans, err)
+	}
+	return err
+}
+
+// export tries to export spans until it succeeds, the retry time is up or
+// ctx is done.
+func (e *retryExporter) export(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	deadline := time.Now().Add(e.config.MaxElapsedTime)
+	interval := e.config.InitialInterval
+	for {
+		err := e.next.ExportSpans(ctx, spans)
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		interval = min(2*interval, e.config.MaxInterval)
+	}
+}
+
+func (e *retryExporter) spoolSpans(spans []sdktrace.ReadOnlySpan, cause error) error {
+	if err := e.spool.write(spans); err != nil {
+		return errors.Join(cause, err)
+	}
+	return fmt.Errorf("%w: %w", ErrSpansSpooled, cause)
+}
+
+func (e *retryExporter) Shutdown(ctx context.Context) error {
+	return e.next.Shutdown(ctx)
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var errExportFailed = errors.New("export failed")
+
+// flakyExporter fails a number of exports before succeeding.
+type flakyExporter struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	exported []string
+}
+
+func (e *flakyExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls++
+	if e.failures != 0 {
+		e.failures--
+		return errExportFailed
+	}
+	for _, s := range spans {
+		e.exported = append(e.exported, s.Name())
+	}
+	return nil
+}
+
+func (e *flakyExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *flakyExporter) setFailures(n int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failures = n
+}
+
+func TestRetryExporter(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Failures    int
+		Config      RetryConfig
+		Err         error
+		Calls       int
+		Exported    []string
+	}{
+		{
+			Description: "Succeeds first time",
+			Config:      RetryConfig{InitialInterval: time.Millisecond},
+			Calls:       1,
+			Exported:    []string{"span"},
+		},
+		{
+			Description: "Succeeds after retries",
+			Failures:    3,
+			Config:      RetryConfig{InitialInterval: time.Millisecond},
+			Calls:       4,
+			Exported:    []string{"span"},
+		},
+		{
+			Description: "Gives up after max elapsed time",
+			Failures:    -1,
+			Config: RetryConfig{
+				InitialInterval: time.Millisecond,
+				MaxInterval:     2 * time.Millisecond,
+				MaxElapsedTime:  20 * time.Millisecond,
+			},
+			Err: errExportFailed,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			next := &flakyExporter{failures: tc.Failures}
+			exporter, err := NewRetryExporter(next, tc.Config)
+			require.NoError(err)
+
+			err = exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{testSpanStub("span").Snapshot()})
+			assert.ErrorIs(err, tc.Err)
+			if tc.Calls != 0 {
+				assert.Equal(tc.Calls, next.calls)
+			} else {
+				assert.Greater(next.calls, 1)
+			}
+			assert.Equal(tc.Exported, next.exported)
+			assert.NoError(exporter.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestRetryExporterContextDone(t *testing.T) {
+	next := &flakyExporter{failures: -1}
+	exporter, err := NewRetryExporter(next, RetryConfig{InitialInterval: time.Hour, MaxElapsedTime: 2 * time.Hour})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = exporter.ExportSpans(ctx, []sdktrace.ReadOnlySpan{testSpanStub("span").Snapshot()})
+	assert.ErrorIs(t, err, errExportFailed)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, next.calls)
+}
+
+func TestRetryExporterSpool(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	next := &flakyExporter{failures: -1}
+	exporter, err := NewRetryExporter(next, RetryConfig{
+		InitialInterval: time.Millisecond,
+		MaxElapsedTime:  time.Millisecond,
+		SpoolDirectory:  t.TempDir(),
+	})
+	require.NoError(err)
+
+	export := func(name string) error {
+		return exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{testSpanStub(name).Snapshot()})
+	}
+
+	// The collector is down: both batches end up in the spool.
+	assert.ErrorIs(export("first"), ErrSpansSpooled)
+	assert.ErrorIs(export("second"), ErrSpansSpooled)
+	assert.Empty(next.exported)
+
+	// The collector recovers: spooled batches go out first, in order.
+	next.setFailures(0)
+	require.NoError(export("third"))
+	assert.Equal([]string{"first", "second", "third"}, next.exported)
+}
+
+func TestNewRetryExporterInvalid(t *testing.T) {
+	_, err := NewRetryExporter(&flakyExporter{}, RetryConfig{
+		InitialInterval: time.Second,
+		MaxInterval:     time.Millisecond,
+		SpoolMaxBytes:   -1,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidRetryValue)
+
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	assert.Equal(t, "retry.maxInterval", fe.Field)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// instrumentationName identifies candlelight as the source of the
+	// telemetry it produces on its own.
+	instrumentationName = "github.com/xmidt-org/candlelight"
+
+	// SpanCallsMetricName is the counter of ended spans.
+	SpanCallsMetricName = "span.calls"
+
+	// SpanDurationMetricName is the histogram of span durations in seconds.
+	SpanDurationMetricName = "span.duration"
+
+	spanNameKey   = attribute.Key("span.name")
+	spanKindKey   = attribute.Key("span.kind")
+	statusCodeKey = attribute.Key("status.code")
+)
+
+// spanDurationBuckets are the bucket boundaries, in seconds, of the span
+// duration histogram. The SDK defaults are sized for milliseconds.
+var spanDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// spanMetricsProcessor derives request rate, error rate and latency (RED)
+// metrics from the spans that end.
+type spanMetricsProcessor struct {
+	calls    metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// NewSpanMetricsProcessor returns a span processor that records a call count
+// and a duration for every span that ends. Both metrics carry the span name,
+// span kind and status code as attributes, so the error rate is the count of
+// calls with a status code of "Error".
+//
+// Only sampled spans reach span processors, so the metrics reflect the
+// sampling decisions of the tracer provider.
+func NewSpanMetricsProcessor(mp metric.MeterProvider) (sdktrace.SpanProcessor, error) {
+	meter := mp.Meter(instrumentationName)
+	calls, err := meter.Int64Counter(SpanCallsMetricName,
+		metric.WithDescription("Number of spans ended, by span name, kind and status."),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating %s counter: %w", SpanCallsMetricName, err)
+	}
+	duration, err := meter.Float64Histogram(SpanDurationMetricName,
+		metric.WithDescription("Duration of spans, by span name, kind and status."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(spanDurationBuckets...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating %s histogram: %w", SpanDurationMetricName, err)
+	}
+	return &spanMetricsProcessor{calls: calls, duration: duration}, nil
+}
+
+func (p *spanMetricsProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p *spanMetricsProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attrs := metric.WithAttributeSet(attribute.NewSet(
+		spanNameKey.String(s.Name()),
+		spanKindKey.String(s.SpanKind().String()),
+		statusCodeKey.String(s.Status().Code.String()),
+	))
+	ctx := context.Background()
+	p.calls.Add(ctx, 1, attrs)
+	p.duration.Record(ctx, s.EndTime().Sub(s.StartTime()).Seconds(), attrs)
+}
+
+func (p *spanMetricsProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *spanMetricsProcessor) ForceFlush(context.Context) error {
+	return nil
+}
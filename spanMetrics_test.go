@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanMetricsProcessor(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	tracing, err := New(Config{
+		Provider:        "stdout",
+		SkipTraceExport: true,
+		MeterProvider:   mp,
+	})
+	require.NoError(err)
+
+	tracer := tracing.TracerProvider().Tracer("test")
+	for i := 0; i < 3; i++ {
+		_, span := tracer.Start(context.Background(), "GET /devices", trace.WithSpanKind(trace.SpanKindServer))
+		if i == 0 {
+			span.SetStatus(codes.Error, "boom")
+		}
+		span.End()
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(reader.Collect(context.Background(), &rm))
+	require.Len(rm.ScopeMetrics, 1)
+	assert.Equal(instrumentationName, rm.ScopeMetrics[0].Scope.Name)
+
+	metrics := make(map[string]metricdata.Metrics)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	calls, ok := metrics[SpanCallsMetricName].Data.(metricdata.Sum[int64])
+	require.True(ok)
+	counts := make(map[string]int64)
+	for _, dp := range calls.DataPoints {
+		name, _ := dp.Attributes.Value(spanNameKey)
+		kind, _ := dp.Attributes.Value(spanKindKey)
+		assert.Equal("GET /devices", name.AsString())
+		assert.Equal("server", kind.AsString())
+		status, _ := dp.Attributes.Value(statusCodeKey)
+		counts[status.AsString()] = dp.Value
+	}
+	assert.Equal(map[string]int64{"Error": 1, "Unset": 2}, counts)
+
+	duration, ok := metrics[SpanDurationMetricName].Data.(metricdata.Histogram[float64])
+	require.True(ok)
+	var total uint64
+	for _, dp := range duration.DataPoints {
+		total += dp.Count
+		assert.Equal(spanDurationBuckets, dp.Bounds)
+	}
+	assert.Equal(uint64(3), total)
+}
+
+func TestSpanMetricsProcessorNoop(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	tracing, err := New(Config{
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+	assert.True(t, tracing.IsNoop())
+
+	_, span := tracing.TracerProvider().Tracer("test").Start(context.Background(), "ignored")
+	span.End()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Empty(t, rm.ScopeMetrics)
+}
+
+// failingMeterProvider returns meters that cannot create instruments.
+type failingMeterProvider struct {
+	metricnoop.MeterProvider
+}
+
+func (failingMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return failingMeter{}
+}
+
+type failingMeter struct {
+	metricnoop.Meter
+}
+
+func (failingMeter) Int64Counter(string, ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return nil, errMeterFailed
+}
+
+var errMeterFailed = errors.New("meter failed")
+
+// shutdownExporter records whether it has been shut down.
+type shutdownExporter struct {
+	sdktrace.SpanExporter
+	shutdown bool
+}
+
+func (e *shutdownExporter) Shutdown(ctx context.Context) error {
+	e.shutdown = true
+	return e.SpanExporter.Shutdown(ctx)
+}
+
+func TestNewSpanMetricsFailureShutsDownProvider(t *testing.T) {
+	exporter := &shutdownExporter{SpanExporter: tracetest.NewInMemoryExporter()}
+	_, err := New(Config{MeterProvider: failingMeterProvider{}}, WithExporter(exporter))
+	require.ErrorIs(t, err, errMeterFailed)
+	assert.True(t, exporter.shutdown)
+}
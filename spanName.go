@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// SpanNameFormatter names the span of an HTTP request. Names should have a
+// low cardinality, so they must not include values such as device IDs that
+// vary from request to request.
+type SpanNameFormatter func(*http.Request) string
+
+// PathSpanName names spans after the request's URL path. Paths often carry
+// identifiers, so prefer one of the route based formatters.
+func PathSpanName(r *http.Request) string {
+	return r.URL.Path
+}
+
+// MethodSpanName names spans after the request's method only, e.g. "GET".
+func MethodSpanName(r *http.Request) string {
+	return r.Method
+}
+
+// ServeMuxSpanName names spans after the net/http ServeMux pattern that
+// matched the request, e.g. "GET /devices/{id}", and falls back to the
+// method when no pattern has matched (yet).
+func ServeMuxSpanName(r *http.Request) string {
+	switch {
+	case r.Pattern == "":
+		return r.Method
+	case strings.Contains(r.Pattern, " "):
+		// The pattern already starts with a method.
+		return r.Pattern
+	default:
+		return r.Method + " " + r.Pattern
+	}
+}
+
+// GorillaMuxSpanName names spans after the gorilla/mux route template that
+// matched the request, e.g. "GET /devices/{id}", and falls back to the
+// method. The route is only known inside the router, so middleware using
+// this formatter must be installed with Router.Use.
+func GorillaMuxSpanName(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return r.Method
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return r.Method
+	}
+	return r.Method + " " + template
+}
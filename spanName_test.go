@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestServeMuxSpanName(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Pattern     string
+		Expected    string
+	}{
+		{Description: "No pattern", Expected: "GET"},
+		{Description: "Path pattern", Pattern: "/devices/{id}", Expected: "GET /devices/{id}"},
+		{Description: "Method pattern", Pattern: "GET /devices/{id}/stat", Expected: "GET /devices/{id}/stat"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/devices/mac:112233445566", nil)
+			r.Pattern = tc.Pattern
+			assert.Equal(t, tc.Expected, ServeMuxSpanName(r))
+		})
+	}
+}
+
+func TestSimpleSpanNames(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/devices/mac:112233445566", nil)
+	assert.Equal(t, "POST", MethodSpanName(r))
+	assert.Equal(t, "/devices/mac:112233445566", PathSpanName(r))
+	assert.Equal(t, "POST", GorillaMuxSpanName(r))
+}
+
+func TestTraceMiddlewareSpanNames(t *testing.T) {
+	serveMux := func(tracing Tracing, formatter SpanNameFormatter) http.Handler {
+		m := http.NewServeMux()
+		m.HandleFunc("GET /devices/{id}", func(http.ResponseWriter, *http.Request) {})
+		tc := TraceConfig{TraceProvider: tracing.TracerProvider(), SpanNameFormatter: formatter}
+		return tc.TraceMiddleware(m)
+	}
+	gorillaMux := func(tracing Tracing, formatter SpanNameFormatter) http.Handler {
+		router := mux.NewRouter()
+		router.HandleFunc("/devices/{id}", func(http.ResponseWriter, *http.Request) {})
+		tc := TraceConfig{TraceProvider: tracing.TracerProvider(), SpanNameFormatter: formatter}
+		router.Use(tc.TraceMiddleware)
+		return router
+	}
+
+	tcs := []struct {
+		Description string
+		Handler     func(Tracing, SpanNameFormatter) http.Handler
+		Formatter   SpanNameFormatter
+		Expected    string
+	}{
+		{
+			Description: "Default path",
+			Handler:     serveMux,
+			Expected:    "/devices/mac:112233445566",
+		},
+		{
+			Description: "ServeMux pattern",
+			Handler:     serveMux,
+			Formatter:   ServeMuxSpanName,
+			Expected:    "GET /devices/{id}",
+		},
+		{
+			Description: "Gorilla route template",
+			Handler:     gorillaMux,
+			Formatter:   GorillaMuxSpanName,
+			Expected:    "GET /devices/{id}",
+		},
+		{
+			Description: "Method only",
+			Handler:     gorillaMux,
+			Formatter:   MethodSpanName,
+			Expected:    "GET",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			handler := tc.Handler(newTestTracing(exporter), tc.Formatter)
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/devices/mac:112233445566", nil))
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tc.Expected, spans[0].Name)
+			assert.Equal(t, instrumentationName, spans[0].InstrumentationScope.Name)
+		})
+	}
+}
@@ -0,0 +1,500 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const spoolFileExt = ".spool"
+
+var (
+	ErrSpoolBatchTooLarge = errors.New("span batch is larger than the spool")
+	ErrInvalidAttribute   = errors.New("invalid attribute")
+)
+
+// spool is a bounded on-disk queue of span batches, one file per batch.
+// Files are named after the time they were written so that a directory
+// listing returns them oldest first.
+type spool struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+	size     int64
+	seq      uint64
+}
+
+func newSpool(dir string, maxBytes int64) (*spool, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed creating spool directory: %w", err)
+	}
+	s := &spool{dir: dir, maxBytes: maxBytes}
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		s.size += f.size
+	}
+	return s, nil
+}
+
+type spoolFile struct {
+	name string
+	size int64
+}
+
+// files lists the spooled batches, oldest first.
+func (s *spool) files() ([]spoolFile, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading spool directory: %w", err)
+	}
+	files := make([]spoolFile, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), spoolFileExt) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, spoolFile{name: e.Name(), size: info.Size()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+	return files, nil
+}
+
+func (s *spool) remove(f spoolFile) {
+	if err := os.Remove(filepath.Join(s.dir, f.name)); err == nil || errors.Is(err, os.ErrNotExist) {
+		s.size -= f.size
+	}
+}
+
+// write appends a batch to the spool, evicting the oldest batches if needed
+// to stay within the size bound.
+func (s *spool) write(spans []sdktrace.ReadOnlySpan) error {
+	data, err := json.Marshal(encodeSpans(spans))
+	if err != nil {
+		return fmt.Errorf("failed encoding spans: %w", err)
+	}
+	if int64(len(data)) > s.maxBytes {
+		return fmt.Errorf("%w: %d bytes", ErrSpoolBatchTooLarge, len(data))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size+int64(len(data)) > s.maxBytes {
+		files, err := s.files()
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			if s.size+int64(len(data)) <= s.maxBytes {
+				break
+			}
+			s.remove(f)
+		}
+	}
+
+	s.seq++
+	name := fmt.Sprintf("%020d-%06d%s", time.Now().UnixNano(), s.seq%1000000, spoolFileExt)
+	tmp := filepath.Join(s.dir, name+".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed writing spool file: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, name)); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed writing spool file: %w", err)
+	}
+	s.size += int64(len(data))
+	return nil
+}
+
+// replay exports the spooled batches oldest first, removing each one once it
+// has been exported. It stops at the first export failure and returns it.
+// Batches that cannot be decoded are discarded and reported to the
+// OpenTelemetry error handler.
+func (s *spool) replay(ctx context.Context, export func(context.Context, []sdktrace.ReadOnlySpan) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size == 0 {
+		return nil
+	}
+	files, err := s.files()
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(s.dir, f.name)) // nolint:gosec
+		if err != nil {
+			return fmt.Errorf("failed reading spool file: %w", err)
+		}
+		var batch []jsonSpan
+		var spans []sdktrace.ReadOnlySpan
+		if err = json.Unmarshal(data, &batch); err == nil {
+			spans, err = decodeSpans(batch)
+		}
+		if err != nil {
+			otel.Handle(fmt.Errorf("discarding spool file %s: %w", f.name, err))
+			s.remove(f)
+			continue
+		}
+		if err := export(ctx, spans); err != nil {
+			return err
+		}
+		s.remove(f)
+	}
+	return nil
+}
+
+// jsonSpan is the on-disk form of a span.
+type jsonSpan struct {
+	Name                 string
+	SpanContext          jsonSpanContext
+	Parent               jsonSpanContext
+	SpanKind             trace.SpanKind
+	StartTime            time.Time
+	EndTime              time.Time
+	Attributes           []jsonKeyValue
+	Events               []jsonEvent
+	Links                []jsonLink
+	Status               jsonStatus
+	DroppedAttributes    int
+	DroppedEvents        int
+	DroppedLinks         int
+	ChildSpanCount       int
+	Resource             jsonResource
+	InstrumentationScope jsonScope
+}
+
+type jsonSpanContext struct {
+	TraceID    string
+	SpanID     string
+	TraceFlags byte
+	TraceState string
+	Remote     bool
+}
+
+type jsonEvent struct {
+	Name                  string
+	Attributes            []jsonKeyValue
+	DroppedAttributeCount int
+	Time                  time.Time
+}
+
+type jsonLink struct {
+	SpanContext           jsonSpanContext
+	Attributes            []jsonKeyValue
+	DroppedAttributeCount int
+}
+
+type jsonStatus struct {
+	Code        codes.Code
+	Description string
+}
+
+type jsonResource struct {
+	SchemaURL  string
+	Attributes []jsonKeyValue
+}
+
+type jsonScope struct {
+	Name       string
+	Version    string
+	SchemaURL  string
+	Attributes []jsonKeyValue
+}
+
+// jsonKeyValue and jsonValue mirror the JSON encoding of attribute.KeyValue
+// and attribute.Value, which is also used by the stdout exporter.
+type jsonKeyValue struct {
+	Key   string
+	Value jsonValue
+}
+
+type jsonValue struct {
+	Type  string
+	Value json.RawMessage
+}
+
+func encodeSpans(spans []sdktrace.ReadOnlySpan) []jsonSpan {
+	batch := make([]jsonSpan, len(spans))
+	for i, ro := range spans {
+		s := tracetest.SpanStubFromReadOnlySpan(ro)
+		js := jsonSpan{
+			Name:              s.Name,
+			SpanContext:       encodeSpanContext(s.SpanContext),
+			Parent:            encodeSpanContext(s.Parent),
+			SpanKind:          s.SpanKind,
+			StartTime:         s.StartTime,
+			EndTime:           s.EndTime,
+			Attributes:        encodeAttributes(s.Attributes),
+			Status:            jsonStatus{Code: s.Status.Code, Description: s.Status.Description},
+			DroppedAttributes: s.DroppedAttributes,
+			DroppedEvents:     s.DroppedEvents,
+			DroppedLinks:      s.DroppedLinks,
+			ChildSpanCount:    s.ChildSpanCount,
+			InstrumentationScope: jsonScope{
+				Name:       s.InstrumentationScope.Name,
+				Version:    s.InstrumentationScope.Version,
+				SchemaURL:  s.InstrumentationScope.SchemaURL,
+				Attributes: encodeAttributes(s.InstrumentationScope.Attributes.ToSlice()),
+			},
+		}
+		if s.Resource != nil {
+			js.Resource = jsonResource{
+				SchemaURL:  s.Resource.SchemaURL(),
+				Attributes: encodeAttributes(s.Resource.Attributes()),
+			}
+		}
+		for _, e := range s.Events {
+			js.Events = append(js.Events, jsonEvent{
+				Name:                  e.Name,
+				Attributes:            encodeAttributes(e.Attributes),
+				DroppedAttributeCount: e.DroppedAttributeCount,
+				Time:                  e.Time,
+			})
+		}
+		for _, l := range s.Links {
+			js.Links = append(js.Links, jsonLink{
+				SpanContext:           encodeSpanContext(l.SpanContext),
+				Attributes:            encodeAttributes(l.Attributes),
+				DroppedAttributeCount: l.DroppedAttributeCount,
+			})
+		}
+		batch[i] = js
+	}
+	return batch
+}
+
+func decodeSpans(batch []jsonSpan) ([]sdktrace.ReadOnlySpan, error) {
+	spans := make([]sdktrace.ReadOnlySpan, 0, len(batch))
+	for _, js := range batch {
+		s, err := decodeSpan(js)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, s.Snapshot())
+	}
+	return spans, nil
+}
+
+func decodeSpan(js jsonSpan) (tracetest.SpanStub, error) {
+	var errs []error
+	decodeAttrs := func(kvs []jsonKeyValue) []attribute.KeyValue {
+		attrs, err := decodeAttributes(kvs)
+		errs = append(errs, err)
+		return attrs
+	}
+	decodeSC := func(jsc jsonSpanContext) trace.SpanContext {
+		sc, err := decodeSpanContext(jsc)
+		errs = append(errs, err)
+		return sc
+	}
+
+	s := tracetest.SpanStub{
+		Name:              js.Name,
+		SpanContext:       decodeSC(js.SpanContext),
+		Parent:            decodeSC(js.Parent),
+		SpanKind:          js.SpanKind,
+		StartTime:         js.StartTime,
+		EndTime:           js.EndTime,
+		Attributes:        decodeAttrs(js.Attributes),
+		Status:            sdktrace.Status{Code: js.Status.Code, Description: js.Status.Description},
+		DroppedAttributes: js.DroppedAttributes,
+		DroppedEvents:     js.DroppedEvents,
+		DroppedLinks:      js.DroppedLinks,
+		ChildSpanCount:    js.ChildSpanCount,
+		Resource:          resource.NewWithAttributes(js.Resource.SchemaURL, decodeAttrs(js.Resource.Attributes)...),
+		InstrumentationScope: instrumentation.Scope{
+			Name:       js.InstrumentationScope.Name,
+			Version:    js.InstrumentationScope.Version,
+			SchemaURL:  js.InstrumentationScope.SchemaURL,
+			Attributes: attribute.NewSet(decodeAttrs(js.InstrumentationScope.Attributes)...),
+		},
+	}
+	for _, e := range js.Events {
+		s.Events = append(s.Events, sdktrace.Event{
+			Name:                  e.Name,
+			Attributes:            decodeAttrs(e.Attributes),
+			DroppedAttributeCount: e.DroppedAttributeCount,
+			Time:                  e.Time,
+		})
+	}
+	for _, l := range js.Links {
+		s.Links = append(s.Links, sdktrace.Link{
+			SpanContext:           decodeSC(l.SpanContext),
+			Attributes:            decodeAttrs(l.Attributes),
+			DroppedAttributeCount: l.DroppedAttributeCount,
+		})
+	}
+	return s, errors.Join(errs...)
+}
+
+func encodeSpanContext(sc trace.SpanContext) jsonSpanContext {
+	if !sc.IsValid() {
+		return jsonSpanContext{}
+	}
+	return jsonSpanContext{
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		TraceFlags: byte(sc.TraceFlags()),
+		TraceState: sc.TraceState().String(),
+		Remote:     sc.IsRemote(),
+	}
+}
+
+func decodeSpanContext(jsc jsonSpanContext) (trace.SpanContext, error) {
+	if jsc.TraceID == "" && jsc.SpanID == "" {
+		return trace.SpanContext{}, nil
+	}
+	traceID, err := trace.TraceIDFromHex(jsc.TraceID)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	spanID, err := trace.SpanIDFromHex(jsc.SpanID)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	state, err := trace.ParseTraceState(jsc.TraceState)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(jsc.TraceFlags),
+		TraceState: state,
+		Remote:     jsc.Remote,
+	}), nil
+}
+
+func encodeAttributes(attrs []attribute.KeyValue) []jsonKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]jsonKeyValue, len(attrs))
+	for i, kv := range attrs {
+		kvs[i] = jsonKeyValue{Key: string(kv.Key), Value: encodeValue(kv.Value)}
+	}
+	return kvs
+}
+
+func encodeValue(v attribute.Value) jsonValue {
+	var data any
+	switch v.Type() {
+	case attribute.SLICE:
+		values := v.AsSlice()
+		encoded := make([]jsonValue, len(values))
+		for i, value := range values {
+			encoded[i] = encodeValue(value)
+		}
+		data = encoded
+	case attribute.MAP:
+		data = encodeAttributes(v.AsMap())
+	default:
+		data = v.AsInterface()
+	}
+	raw, _ := json.Marshal(data)
+	return jsonValue{Type: v.Type().String(), Value: raw}
+}
+
+func decodeAttributes(kvs []jsonKeyValue) ([]attribute.KeyValue, error) {
+	if len(kvs) == 0 {
+		return nil, nil
+	}
+	attrs := make([]attribute.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		v, err := decodeValue(kv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrInvalidAttribute, kv.Key, err)
+		}
+		attrs = append(attrs, attribute.KeyValue{Key: attribute.Key(kv.Key), Value: v})
+	}
+	return attrs, nil
+}
+
+// nolint:funlen
+func decodeValue(jv jsonValue) (attribute.Value, error) {
+	var err error
+	switch jv.Type {
+	case attribute.BOOL.String():
+		var v bool
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.BoolValue(v), err
+	case attribute.INT64.String():
+		var v int64
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.Int64Value(v), err
+	case attribute.FLOAT64.String():
+		var v float64
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.Float64Value(v), err
+	case attribute.STRING.String():
+		var v string
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.StringValue(v), err
+	case attribute.BOOLSLICE.String():
+		var v []bool
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.BoolSliceValue(v), err
+	case attribute.INT64SLICE.String():
+		var v []int64
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.Int64SliceValue(v), err
+	case attribute.FLOAT64SLICE.String():
+		var v []float64
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.Float64SliceValue(v), err
+	case attribute.STRINGSLICE.String():
+		var v []string
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.StringSliceValue(v), err
+	case attribute.BYTESLICE.String():
+		var v []byte
+		err = json.Unmarshal(jv.Value, &v)
+		return attribute.ByteSliceValue(v), err
+	case attribute.SLICE.String():
+		var encoded []jsonValue
+		if err = json.Unmarshal(jv.Value, &encoded); err != nil {
+			return attribute.Value{}, err
+		}
+		values := make([]attribute.Value, len(encoded))
+		for i, e := range encoded {
+			if values[i], err = decodeValue(e); err != nil {
+				return attribute.Value{}, err
+			}
+		}
+		return attribute.SliceValue(values...), nil
+	case attribute.MAP.String():
+		var encoded []jsonKeyValue
+		if err = json.Unmarshal(jv.Value, &encoded); err != nil {
+			return attribute.Value{}, err
+		}
+		kvs, err := decodeAttributes(encoded)
+		return attribute.MapValue(kvs...), err
+	}
+	return attribute.Value{}, fmt.Errorf("unsupported type %q", jv.Type)
+}
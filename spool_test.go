@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanStub(name string) tracetest.SpanStub {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	parentID, _ := trace.SpanIDFromHex("53995c3f42cd8ad8")
+	state, _ := trace.ParseTraceState("rojo=00f067aa0ba902b7")
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	return tracetest.SpanStub{
+		Name: name,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			TraceState: state,
+		}),
+		Parent: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  parentID,
+			Remote:  true,
+		}),
+		SpanKind:  trace.SpanKindServer,
+		StartTime: start,
+		EndTime:   start.Add(time.Second),
+		Attributes: []attribute.KeyValue{
+			attribute.Bool("bool", true),
+			attribute.Int64("int", 42),
+			attribute.Float64("float", 1.5),
+			attribute.String("string", "value"),
+			attribute.BoolSlice("bools", []bool{true, false}),
+			attribute.Int64Slice("ints", []int64{1, 2}),
+			attribute.Float64Slice("floats", []float64{0.5, 2.5}),
+			attribute.StringSlice("strings", []string{"a", "b"}),
+			attribute.ByteSlice("bytes", []byte("raw")),
+			attribute.Slice("slice", attribute.StringValue("x"), attribute.IntValue(1)),
+			attribute.Map("map", attribute.String("nested", "y")),
+		},
+		Events: []sdktrace.Event{{
+			Name:       "event",
+			Attributes: []attribute.KeyValue{attribute.String("k", "v")},
+			Time:       start.Add(time.Millisecond),
+		}},
+		Links: []sdktrace.Link{{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: parentID}),
+			Attributes:  []attribute.KeyValue{attribute.String("link", "attr")},
+		}},
+		Status:            sdktrace.Status{Code: codes.Error, Description: "boom"},
+		DroppedAttributes: 1,
+		DroppedEvents:     2,
+		DroppedLinks:      3,
+		ChildSpanCount:    4,
+		Resource:          resource.NewWithAttributes("https://schema", attribute.String("service.name", "test")),
+		InstrumentationScope: instrumentation.Scope{
+			Name:       "scope",
+			Version:    "v1",
+			SchemaURL:  "https://scope",
+			Attributes: attribute.NewSet(attribute.String("scope", "attr")),
+		},
+	}
+}
+
+func TestSpanEncodingRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	stub := testSpanStub("round trip")
+	data, err := json.Marshal(encodeSpans([]sdktrace.ReadOnlySpan{stub.Snapshot()}))
+	require.NoError(err)
+
+	var batch []jsonSpan
+	require.NoError(json.Unmarshal(data, &batch))
+	spans, err := decodeSpans(batch)
+	require.NoError(err)
+	require.Len(spans, 1)
+
+	decoded := tracetest.SpanStubFromReadOnlySpan(spans[0])
+	assert.True(stub.StartTime.Equal(decoded.StartTime))
+	assert.True(stub.EndTime.Equal(decoded.EndTime))
+	decoded.StartTime, decoded.EndTime = stub.StartTime, stub.EndTime
+	decoded.Events[0].Time = stub.Events[0].Time
+	decoded.InstrumentationLibrary = stub.InstrumentationLibrary
+	assert.Equal(stub, decoded)
+}
+
+func TestDecodeValueUnsupported(t *testing.T) {
+	_, err := decodeAttributes([]jsonKeyValue{{Key: "k", Value: jsonValue{Type: "COMPLEX", Value: json.RawMessage(`1`)}}})
+	assert.ErrorIs(t, err, ErrInvalidAttribute)
+}
+
+func TestSpool(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := filepath.Join(t.TempDir(), "spool")
+	s, err := newSpool(dir, 1<<20)
+	require.NoError(err)
+
+	require.NoError(s.write([]sdktrace.ReadOnlySpan{testSpanStub("first").Snapshot()}))
+	require.NoError(s.write([]sdktrace.ReadOnlySpan{testSpanStub("second").Snapshot()}))
+	files, err := s.files()
+	require.NoError(err)
+	assert.Len(files, 2)
+
+	// A new spool over the same directory picks up the existing batches.
+	reopened, err := newSpool(dir, 1<<20)
+	require.NoError(err)
+	assert.Equal(s.size, reopened.size)
+
+	errUnavailable := errors.New("unavailable")
+	var names []string
+	err = s.replay(context.Background(), func(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+		names = append(names, spans[0].Name())
+		if len(names) == 2 {
+			return errUnavailable
+		}
+		return nil
+	})
+	assert.ErrorIs(err, errUnavailable)
+	assert.Equal([]string{"first", "second"}, names)
+
+	names = nil
+	require.NoError(s.replay(context.Background(), func(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+		names = append(names, spans[0].Name())
+		return nil
+	}))
+	assert.Equal([]string{"second"}, names)
+	assert.Zero(s.size)
+	files, err = s.files()
+	require.NoError(err)
+	assert.Empty(files)
+}
+
+func TestSpoolEviction(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	data, err := json.Marshal(encodeSpans([]sdktrace.ReadOnlySpan{testSpanStub("0").Snapshot()}))
+	require.NoError(err)
+
+	s, err := newSpool(t.TempDir(), int64(2*len(data)))
+	require.NoError(err)
+	for _, name := range []string{"1", "2", "3"} {
+		require.NoError(s.write([]sdktrace.ReadOnlySpan{testSpanStub(name).Snapshot()}))
+	}
+
+	var names []string
+	require.NoError(s.replay(context.Background(), func(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+		names = append(names, spans[0].Name())
+		return nil
+	}))
+	assert.Equal([]string{"2", "3"}, names)
+
+	tiny, err := newSpool(t.TempDir(), 10)
+	require.NoError(err)
+	assert.ErrorIs(tiny.write([]sdktrace.ReadOnlySpan{testSpanStub("big").Snapshot()}), ErrSpoolBatchTooLarge)
+}
+
+func TestSpoolDiscardsCorruptFiles(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, "00000000000000000000-000000.spool"), []byte("not json"), 0o600))
+	s, err := newSpool(dir, 1<<20)
+	require.NoError(err)
+	require.NoError(s.write([]sdktrace.ReadOnlySpan{testSpanStub("good").Snapshot()}))
+
+	var names []string
+	require.NoError(s.replay(context.Background(), func(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+		names = append(names, spans[0].Name())
+		return nil
+	}))
+	assert.Equal([]string{"good"}, names)
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	assert.Empty(entries)
+}
@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tail sampling defaults used for the zero values of TailSamplingConfig.
+const (
+	DefaultTailSamplingDecisionWait     = 10 * time.Second
+	DefaultTailSamplingMaxTraces        = 10000
+	DefaultTailSamplingMaxSpansPerTrace = 1000
+	DefaultTailSamplingMaxSpans         = 20000
+)
+
+var ErrInvalidTailSamplingValue = errors.New("invalid tail sampling value provided in configuration")
+
+// TailSamplingConfig configures keeping or dropping whole traces once their
+// spans have been seen, rather than when they start. A trace is kept if any
+// of its spans matches one of the policies: KeepErrors, MinDuration or
+// Attributes.
+//
+// Only spans sampled by the head sampler reach the tail sampler, so it is
+// meant to be used with a sampler recording most traces, e.g. noParent set
+// to "always".
+type TailSamplingConfig struct {
+	// Enabled turns on tail sampling for the built-in providers.
+	Enabled bool `json:"enabled"`
+
+	// DecisionWait is how long the spans of a trace are buffered, starting
+	// when the first one ends, before the trace is kept or dropped. Spans
+	// ending after the decision follow it, except that a span matching a
+	// policy turns a drop into a keep: it and the following spans of the
+	// trace are kept, while the spans dropped before are lost. Defaults to
+	// 10s.
+	DecisionWait time.Duration `json:"decisionWait"`
+
+	// MaxTraces bounds the number of traces buffered. When it is reached,
+	// the oldest trace is decided early. Defaults to 10000.
+	MaxTraces int `json:"maxTraces"`
+
+	// MaxSpansPerTrace bounds the spans buffered for a single trace. A trace
+	// reaching it is decided early. Defaults to 1000.
+	MaxSpansPerTrace int `json:"maxSpansPerTrace"`
+
+	// MaxSpans bounds the spans buffered for all traces, and so the memory
+	// used. When it is reached, the oldest traces are decided early.
+	// Defaults to 20000.
+	MaxSpans int `json:"maxSpans"`
+
+	// KeepErrors keeps traces with a span whose status is Error.
+	KeepErrors bool `json:"keepErrors"`
+
+	// MinDuration, if set, keeps traces with a span lasting at least as long.
+	MinDuration time.Duration `json:"minDuration"`
+
+	// Attributes keeps traces with a span matching any of the rules.
+	Attributes []TailSamplingAttribute `json:"attributes"`
+}
+
+// TailSamplingAttribute matches spans carrying the attribute Key with one of
+// Values, compared to the string form of the attribute value. Empty Values
+// match any span carrying the attribute.
+type TailSamplingAttribute struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+// validate reports the invalid fields of the tail sampling configuration.
+func (c TailSamplingConfig) validate() []error {
+	var errs []error
+	check := func(field string, invalid bool, reason string) {
+		if invalid {
+			errs = append(errs, &FieldError{
+				Field: "tailSampling." + field,
+				Err:   fmt.Errorf("%w: %s", ErrInvalidTailSamplingValue, reason),
+			})
+		}
+	}
+	check("decisionWait", c.DecisionWait < 0, "negative duration")
+	check("maxTraces", c.MaxTraces < 0, "negative count")
+	check("maxSpansPerTrace", c.MaxSpansPerTrace < 0, "negative count")
+	check("maxSpans", c.MaxSpans < 0, "negative count")
+	check("minDuration", c.MinDuration < 0, "negative duration")
+	for i, a := range c.Attributes {
+		check(fmt.Sprintf("attributes[%d].key", i), a.Key == "", "empty key")
+	}
+	check("enabled", c.Enabled && !c.KeepErrors && c.MinDuration == 0 && len(c.Attributes) == 0,
+		"no policy set, every trace would be dropped")
+	return errs
+}
+
+func (c TailSamplingConfig) withDefaults() TailSamplingConfig {
+	if c.DecisionWait == 0 {
+		c.DecisionWait = DefaultTailSamplingDecisionWait
+	}
+	if c.MaxTraces == 0 {
+		c.MaxTraces = DefaultTailSamplingMaxTraces
+	}
+	if c.MaxSpansPerTrace == 0 {
+		c.MaxSpansPerTrace = DefaultTailSamplingMaxSpansPerTrace
+	}
+	if c.MaxSpans == 0 {
+		c.MaxSpans = DefaultTailSamplingMaxSpans
+	}
+	return c
+}
+
+// keeps reports whether s matches one of the policies.
+func (c TailSamplingConfig) keeps(s sdktrace.ReadOnlySpan) bool {
+	if c.KeepErrors && s.Status().Code == codes.Error {
+		return true
+	}
+	if c.MinDuration > 0 && s.EndTime().Sub(s.StartTime()) >= c.MinDuration {
+		return true
+	}
+	for _, rule := range c.Attributes {
+		for _, kv := range s.Attributes() {
+			if string(kv.Key) == rule.Key && (len(rule.Values) == 0 || slices.Contains(rule.Values, kv.Value.Emit())) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bufferedTrace holds the ended spans of a trace awaiting a decision.
+type bufferedTrace struct {
+	id       trace.TraceID
+	spans    []sdktrace.ReadOnlySpan
+	deadline time.Time
+	keep     bool
+}
+
+// tailSamplingProcessor buffers spans per trace and hands the spans of the
+// traces it keeps to the next processor.
+type tailSamplingProcessor struct {
+	config TailSamplingConfig
+	next   sdktrace.SpanProcessor
+	now    func() time.Time
+
+	mu       sync.Mutex
+	pending  map[trace.TraceID]*list.Element
+	order    *list.List // of *bufferedTrace, oldest first
+	buffered int        // spans in pending
+
+	// decided remembers recent decisions so that late spans follow them.
+	// decidedIDs is a ring of the same trace IDs, used to evict the oldest.
+	decided     map[trace.TraceID]bool
+	decidedIDs  []trace.TraceID
+	decidedNext int
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewTailSamplingProcessor returns a span processor buffering ended spans
+// per trace, as described by config, and passing the spans of the traces it
+// keeps on to next. The Enabled field is ignored. The built-in providers
+// install it automatically when Config.TailSampling is enabled.
+func NewTailSamplingProcessor(config TailSamplingConfig, next sdktrace.SpanProcessor) (sdktrace.SpanProcessor, error) {
+	config.Enabled = true
+	if err := errors.Join(config.validate()...); err != nil {
+		return nil, err
+	}
+	p := newTailSamplingProcessor(config.withDefaults(), next, time.Now)
+	go p.run(max(p.config.DecisionWait/10, 10*time.Millisecond))
+	return p, nil
+}
+
+func newTailSamplingProcessor(config TailSamplingConfig, next sdktrace.SpanProcessor, now func() time.Time) *tailSamplingProcessor {
+	return &tailSamplingProcessor{
+		config:     config,
+		next:       next,
+		now:        now,
+		pending:    make(map[trace.TraceID]*list.Element),
+		order:      list.New(),
+		decided:    make(map[trace.TraceID]bool),
+		decidedIDs: make([]trace.TraceID, config.MaxTraces),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// run decides the traces whose wait is over until the processor is shut
+// down.
+func (p *tailSamplingProcessor) run(interval time.Duration) {
+	defer close(p.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.forward(p.decideExpired())
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+func (p *tailSamplingProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+func (p *tailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+	id := s.SpanContext().TraceID()
+
+	p.mu.Lock()
+	if keep, ok := p.decided[id]; ok {
+		if !keep && p.config.keeps(s) {
+			keep = true
+			p.decided[id] = true
+		}
+		p.mu.Unlock()
+		if keep {
+			p.next.OnEnd(s)
+		}
+		return
+	}
+
+	e, ok := p.pending[id]
+	if !ok {
+		e = p.order.PushBack(&bufferedTrace{id: id, deadline: p.now().Add(p.config.DecisionWait)})
+		p.pending[id] = e
+	}
+	t := e.Value.(*bufferedTrace)
+	t.spans = append(t.spans, s)
+	t.keep = t.keep || p.config.keeps(s)
+	p.buffered++
+
+	var ready []*bufferedTrace
+	if len(t.spans) >= p.config.MaxSpansPerTrace {
+		ready = append(ready, p.decide(e))
+	}
+	for p.order.Len() > p.config.MaxTraces || p.buffered > p.config.MaxSpans {
+		ready = append(ready, p.decide(p.order.Front()))
+	}
+	p.mu.Unlock()
+
+	p.forward(ready)
+}
+
+// decide removes a trace from the buffer and records whether it is kept.
+// p.mu must be held.
+func (p *tailSamplingProcessor) decide(e *list.Element) *bufferedTrace {
+	t := p.order.Remove(e).(*bufferedTrace)
+	delete(p.pending, t.id)
+	p.buffered -= len(t.spans)
+
+	if old := p.decidedIDs[p.decidedNext]; old.IsValid() {
+		delete(p.decided, old)
+	}
+	p.decidedIDs[p.decidedNext] = t.id
+	p.decidedNext = (p.decidedNext + 1) % len(p.decidedIDs)
+	p.decided[t.id] = t.keep
+	return t
+}
+
+// decideExpired decides the traces whose wait is over.
+func (p *tailSamplingProcessor) decideExpired() []*bufferedTrace {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	var ready []*bufferedTrace
+	for e := p.order.Front(); e != nil && !e.Value.(*bufferedTrace).deadline.After(now); e = p.order.Front() {
+		ready = append(ready, p.decide(e))
+	}
+	return ready
+}
+
+// decideAll decides every buffered trace.
+func (p *tailSamplingProcessor) decideAll() []*bufferedTrace {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ready []*bufferedTrace
+	for p.order.Len() > 0 {
+		ready = append(ready, p.decide(p.order.Front()))
+	}
+	return ready
+}
+
+// forward hands the spans of the kept traces to the next processor.
+func (p *tailSamplingProcessor) forward(traces []*bufferedTrace) {
+	for _, t := range traces {
+		if !t.keep {
+			continue
+		}
+		for _, s := range t.spans {
+			p.next.OnEnd(s)
+		}
+	}
+}
+
+// Shutdown decides every buffered trace before shutting down the next
+// processor.
+func (p *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+		<-p.done
+	})
+	p.forward(p.decideAll())
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush decides every buffered trace, without waiting for the rest of
+// their spans, before flushing the next processor.
+func (p *tailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	p.forward(p.decideAll())
+	return p.next.ForceFlush(ctx)
+}
@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/candlelight/collectortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tailSamplingTest drives a tail sampling processor with a fake clock.
+type tailSamplingTest struct {
+	processor *tailSamplingProcessor
+	recorder  *tracetest.SpanRecorder
+	tracer    trace.Tracer
+	now       time.Time
+}
+
+func newTailSamplingTest(t *testing.T, config TailSamplingConfig) *tailSamplingTest {
+	tst := &tailSamplingTest{
+		recorder: tracetest.NewSpanRecorder(),
+		now:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}
+	tst.processor = newTailSamplingProcessor(config.withDefaults(), tst.recorder, func() time.Time { return tst.now })
+	go tst.processor.run(time.Hour)
+	t.Cleanup(func() { _ = tst.processor.Shutdown(context.Background()) })
+	tst.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tst.processor)).Tracer("test")
+	return tst
+}
+
+// trace records a trace made of a root span and one child per function,
+// which may alter the child before it ends.
+func (tst *tailSamplingTest) trace(children ...func(trace.Span)) trace.TraceID {
+	ctx, root := tst.tracer.Start(context.Background(), "root", trace.WithTimestamp(tst.now))
+	for _, f := range children {
+		_, child := tst.tracer.Start(ctx, "child", trace.WithTimestamp(tst.now))
+		f(child)
+		child.End(trace.WithTimestamp(tst.now.Add(time.Millisecond)))
+	}
+	root.End(trace.WithTimestamp(tst.now.Add(time.Millisecond)))
+	return root.SpanContext().TraceID()
+}
+
+func (tst *tailSamplingTest) expire() {
+	tst.now = tst.now.Add(tst.processor.config.DecisionWait)
+	tst.processor.forward(tst.processor.decideExpired())
+}
+
+// exported returns the number of exported spans per trace.
+func (tst *tailSamplingTest) exported() map[trace.TraceID]int {
+	counts := make(map[trace.TraceID]int)
+	for _, s := range tst.recorder.Ended() {
+		counts[s.SpanContext().TraceID()]++
+	}
+	return counts
+}
+
+func nothing(trace.Span) {}
+
+func failed(s trace.Span) { s.SetStatus(codes.Error, "failed") }
+
+func TestTailSamplingPolicies(t *testing.T) {
+	slow := func(s trace.Span) { s.End(trace.WithTimestamp(time.Date(2026, 10, 19, 12, 0, 2, 0, time.UTC))) }
+	tagged := func(value string) func(trace.Span) {
+		return func(s trace.Span) { s.SetAttributes(attribute.String("device.id", value)) }
+	}
+
+	tcs := []struct {
+		Description string
+		Config      TailSamplingConfig
+		Child       func(trace.Span)
+		Kept        bool
+	}{
+		{Description: "Error kept", Config: TailSamplingConfig{KeepErrors: true}, Child: failed, Kept: true},
+		{Description: "No error dropped", Config: TailSamplingConfig{KeepErrors: true}, Child: nothing},
+		{Description: "Error ignored", Config: TailSamplingConfig{MinDuration: time.Second}, Child: failed},
+		{Description: "Slow kept", Config: TailSamplingConfig{MinDuration: time.Second}, Child: slow, Kept: true},
+		{Description: "Fast dropped", Config: TailSamplingConfig{MinDuration: time.Second}, Child: nothing},
+		{
+			Description: "Attribute value kept",
+			Config:      TailSamplingConfig{Attributes: []TailSamplingAttribute{{Key: "device.id", Values: []string{"a", "b"}}}},
+			Child:       tagged("b"),
+			Kept:        true,
+		},
+		{
+			Description: "Attribute value dropped",
+			Config:      TailSamplingConfig{Attributes: []TailSamplingAttribute{{Key: "device.id", Values: []string{"a", "b"}}}},
+			Child:       tagged("c"),
+		},
+		{
+			Description: "Attribute presence kept",
+			Config:      TailSamplingConfig{Attributes: []TailSamplingAttribute{{Key: "device.id"}}},
+			Child:       tagged("c"),
+			Kept:        true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			tst := newTailSamplingTest(t, tc.Config)
+
+			id := tst.trace(nothing, tc.Child)
+			assert.Empty(tst.exported(), "spans must be held until the decision")
+
+			tst.expire()
+			if tc.Kept {
+				assert.Equal(map[trace.TraceID]int{id: 3}, tst.exported())
+			} else {
+				assert.Empty(tst.exported())
+			}
+		})
+	}
+}
+
+func TestTailSamplingLateSpans(t *testing.T) {
+	assert := assert.New(t)
+	tst := newTailSamplingTest(t, TailSamplingConfig{KeepErrors: true})
+
+	ctx, root := tst.tracer.Start(context.Background(), "root")
+	_, early := tst.tracer.Start(ctx, "early")
+	early.SetStatus(codes.Error, "failed")
+	early.End()
+	tst.expire()
+	assert.Len(tst.recorder.Ended(), 1)
+
+	root.End()
+	assert.Len(tst.recorder.Ended(), 2, "late spans follow a keep decision")
+
+	dropped := tst.trace(nothing)
+	tst.expire()
+	droppedCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    dropped,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	_, late := tst.tracer.Start(droppedCtx, "late")
+	late.End()
+	assert.Len(tst.recorder.Ended(), 2, "late spans follow a drop decision")
+
+	_, lateError := tst.tracer.Start(droppedCtx, "late error")
+	lateError.SetStatus(codes.Error, "too late")
+	lateError.End()
+	assert.Len(tst.recorder.Ended(), 3, "a late span matching a policy overrides a drop decision")
+	_, after := tst.tracer.Start(droppedCtx, "after")
+	after.End()
+	assert.Len(tst.recorder.Ended(), 4, "later spans follow the new decision")
+}
+
+func TestTailSamplingBounds(t *testing.T) {
+	t.Run("MaxTraces", func(t *testing.T) {
+		tst := newTailSamplingTest(t, TailSamplingConfig{KeepErrors: true, MaxTraces: 2})
+		first := tst.trace(failed)
+		second := tst.trace(failed)
+		assert.Empty(t, tst.exported())
+
+		tst.trace(failed)
+		assert.Equal(t, map[trace.TraceID]int{first: 2}, tst.exported(), "the oldest trace is decided early")
+		tst.trace(nothing)
+		assert.Equal(t, map[trace.TraceID]int{first: 2, second: 2}, tst.exported())
+	})
+
+	t.Run("MaxSpansPerTrace", func(t *testing.T) {
+		tst := newTailSamplingTest(t, TailSamplingConfig{KeepErrors: true, MaxSpansPerTrace: 2})
+		ctx, root := tst.tracer.Start(context.Background(), "root")
+		_, a := tst.tracer.Start(ctx, "a")
+		failed(a)
+		a.End()
+		assert.Empty(t, tst.exported())
+		_, b := tst.tracer.Start(ctx, "b")
+		b.End()
+		assert.Len(t, tst.recorder.Ended(), 2, "a full trace is decided early")
+		root.End()
+		assert.Len(t, tst.recorder.Ended(), 3)
+	})
+
+	t.Run("MaxSpans", func(t *testing.T) {
+		tst := newTailSamplingTest(t, TailSamplingConfig{KeepErrors: true, MaxSpans: 5})
+		first := tst.trace(failed)
+		second := tst.trace(failed)
+		assert.Empty(t, tst.exported())
+		assert.Equal(t, 4, tst.processor.buffered)
+
+		tst.trace(failed)
+		assert.Equal(t, map[trace.TraceID]int{first: 2}, tst.exported(), "the oldest trace is decided early")
+		assert.Equal(t, 4, tst.processor.buffered)
+		tst.expire()
+		assert.Equal(t, 3, len(tst.exported()))
+		assert.Equal(t, 2, tst.exported()[second])
+		assert.Zero(t, tst.processor.buffered)
+	})
+
+	t.Run("Decisions", func(t *testing.T) {
+		tst := newTailSamplingTest(t, TailSamplingConfig{KeepErrors: true, MaxTraces: 1})
+		tst.trace(nothing)
+		tst.expire()
+		tst.trace(nothing)
+		tst.expire()
+		assert.Len(t, tst.processor.decided, 1, "decisions are bounded by MaxTraces")
+	})
+}
+
+func TestTailSamplingFlush(t *testing.T) {
+	assert := assert.New(t)
+	tst := newTailSamplingTest(t, TailSamplingConfig{KeepErrors: true})
+
+	first := tst.trace(failed)
+	tst.trace(nothing)
+	assert.NoError(tst.processor.ForceFlush(context.Background()))
+	assert.Equal(map[trace.TraceID]int{first: 2}, tst.exported())
+
+	second := tst.trace(failed)
+	assert.NoError(tst.processor.Shutdown(context.Background()))
+	assert.Equal(map[trace.TraceID]int{first: 2, second: 2}, tst.exported())
+	assert.NoError(tst.processor.Shutdown(context.Background()))
+}
+
+func TestNewTailSamplingProcessor(t *testing.T) {
+	next := sdktrace.NewSimpleSpanProcessor(tracetest.NewInMemoryExporter())
+
+	_, err := NewTailSamplingProcessor(TailSamplingConfig{}, next)
+	assert.ErrorIs(t, err, ErrInvalidTailSamplingValue)
+
+	_, err = NewTailSamplingProcessor(TailSamplingConfig{KeepErrors: true, Attributes: []TailSamplingAttribute{{}}}, next)
+	var fe *FieldError
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, "tailSampling.attributes[0].key", fe.Field)
+
+	processor, err := NewTailSamplingProcessor(TailSamplingConfig{KeepErrors: true, DecisionWait: time.Millisecond}, next)
+	require.NoError(t, err)
+	assert.NoError(t, processor.Shutdown(context.Background()))
+}
+
+func TestTailSamplingExport(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	collector, err := collectortest.New()
+	require.NoError(err)
+	defer collector.Close()
+
+	tp, err := ConfigureTracerProvider(Config{
+		Provider:     "otlp/http",
+		Endpoint:     collector.HTTPEndpoint,
+		ParentBased:  "honor",
+		NoParent:     "always",
+		TailSampling: TailSamplingConfig{Enabled: true, KeepErrors: true, DecisionWait: 10 * time.Millisecond},
+	})
+	require.NoError(err)
+	sdkProvider, ok := tp.(*sdktrace.TracerProvider)
+	require.True(ok)
+
+	_, fine := tp.Tracer("test").Start(context.Background(), "fine")
+	fine.End()
+	_, failure := tp.Tracer("test").Start(context.Background(), "failure")
+	failure.SetStatus(codes.Error, "failed")
+	failure.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(sdkProvider.Shutdown(ctx))
+
+	spans := collector.Spans()
+	require.Len(spans, 1)
+	assert.Equal("failure", spans[0].Name)
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMalformedTraceHeader is returned for trace header entries that cannot be
+// split into a key and a value.
+var ErrMalformedTraceHeader = errors.New("malformed trace header")
+
+// ParseTraceHeader splits a trace header entry, as carried in WRP message
+// headers or the header named by Config.HeaderPrefix, into a key and a value.
+// Entries take the form "key: value" or "key=value"; they are split at the
+// first separator only, so values such as tracestate lists keep any ":" or
+// "=" they contain. The key is lower-cased and surrounding spaces are trimmed
+// from both parts.
+func ParseTraceHeader(header string) (key, value string, err error) {
+	i := strings.IndexAny(header, ":=")
+	if i < 0 {
+		return "", "", fmt.Errorf("%w: %q has no separator", ErrMalformedTraceHeader, header)
+	}
+
+	key = strings.ToLower(strings.TrimSpace(header[:i]))
+	value = strings.TrimSpace(header[i+1:])
+	switch {
+	case key == "":
+		return "", "", fmt.Errorf("%w: %q has no key", ErrMalformedTraceHeader, header)
+	case strings.ContainsAny(key, " \t"):
+		return "", "", fmt.Errorf("%w: %q has a key containing spaces", ErrMalformedTraceHeader, header)
+	case value == "":
+		return "", "", fmt.Errorf("%w: %q has no value", ErrMalformedTraceHeader, header)
+	}
+	return key, value, nil
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestParseTraceHeader(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Header      string
+		Key         string
+		Value       string
+		Err         bool
+	}{
+		{Description: "Colon", Header: "traceparent: " + testTraceParent, Key: "traceparent", Value: testTraceParent},
+		{Description: "Colon no space", Header: "traceparent:" + testTraceParent, Key: "traceparent", Value: testTraceParent},
+		{Description: "Equals", Header: "traceparent=" + testTraceParent, Key: "traceparent", Value: testTraceParent},
+		{Description: "Case insensitive key", Header: "TraceParent: " + testTraceParent, Key: "traceparent", Value: testTraceParent},
+		{Description: "Value with colons", Header: "tracestate: vendor=a:b:c", Key: "tracestate", Value: "vendor=a:b:c"},
+		{Description: "Value with equals", Header: "tracestate=rojo=00f067aa0ba902b7,congo=t61rcWkgMzE", Key: "tracestate", Value: "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE"},
+		{Description: "Surrounding spaces", Header: "  tracestate :  rojo=1  ", Key: "tracestate", Value: "rojo=1"},
+		{Description: "No separator", Header: "traceparent", Err: true},
+		{Description: "No key", Header: ": value", Err: true},
+		{Description: "No value", Header: "traceparent: ", Err: true},
+		{Description: "Key with spaces", Header: "trace parent: value", Err: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			key, value, err := ParseTraceHeader(tc.Header)
+			if tc.Err {
+				assert.ErrorIs(t, err, ErrMalformedTraceHeader)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Key, key)
+			assert.Equal(t, tc.Value, value)
+		})
+	}
+}
+
+func TestEchoFirstTraceNodeInfoHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	var errs []error
+	var sc trace.SpanContext
+	tracing := Tracing{headerPrefix: "X-Midt-Headers"}
+	handler := EchoFirstTraceNodeInfo(tracing, false, WithHeaderErrorHook(func(err error) {
+		errs = append(errs, err)
+	}))(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		sc = trace.SpanContextFromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("X-Midt-Headers", "Traceparent="+testTraceParent)
+	r.Header.Add("X-Midt-Headers", "tracestate: vendor=a:b")
+	r.Header.Add("X-Midt-Headers", "garbage")
+	r.Header.Add("X-Midt-Headers", "")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.True(sc.IsValid())
+	assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.Equal("vendor=a:b", sc.TraceState().String())
+	if assert.Len(errs, 1) {
+		assert.ErrorIs(errs[0], ErrMalformedTraceHeader)
+		assert.Contains(errs[0].Error(), "garbage")
+	}
+}
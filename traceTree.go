@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var ErrInvalidStdoutSpan = errors.New("invalid stdout span")
+
+// TraceTree is a trace assembled from spans written by the stdout provider.
+type TraceTree struct {
+	TraceID trace.TraceID
+
+	// Roots are the spans without a parent in the trace, followed by the
+	// spans whose parent was not found in the input, ordered by start time,
+	// and by the earliest span of each cycle of parents found in the input.
+	Roots []*TraceTreeSpan
+}
+
+// TraceTreeSpan is a span of a TraceTree.
+type TraceTreeSpan struct {
+	Name              string
+	SpanID            trace.SpanID
+	ParentSpanID      trace.SpanID
+	SpanKind          trace.SpanKind
+	StartTime         time.Time
+	EndTime           time.Time
+	StatusCode        codes.Code
+	StatusDescription string
+
+	// Children are the spans started from this one, ordered by start time.
+	Children []*TraceTreeSpan
+
+	// cyclic is set on the roots promoted to break a cycle of parents.
+	cyclic bool
+}
+
+// Duration returns how long the span lasted, or zero if it has not ended.
+func (s *TraceTreeSpan) Duration() time.Duration {
+	if s.EndTime.Before(s.StartTime) {
+		return 0
+	}
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// stdoutSpan holds the fields of a span written by the stdout exporter that
+// are needed to build a TraceTree.
+type stdoutSpan struct {
+	Name        string
+	SpanContext stdoutSpanContext
+	Parent      stdoutSpanContext
+	SpanKind    trace.SpanKind
+	StartTime   time.Time
+	EndTime     time.Time
+	Status      jsonStatus
+}
+
+type stdoutSpanContext struct {
+	TraceID string
+	SpanID  string
+}
+
+// ReadTraceTrees reads the JSON spans written by the stdout provider, pretty
+// printed or not, and groups them into traces ordered by the start time of
+// their first span.
+func ReadTraceTrees(r io.Reader) ([]TraceTree, error) {
+	byTrace := make(map[trace.TraceID][]*TraceTreeSpan)
+	var order []trace.TraceID
+
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var s stdoutSpan
+		err := dec.Decode(&s)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: span %d: %w", ErrInvalidStdoutSpan, n, err)
+		}
+		traceID, span, err := s.treeSpan()
+		if err != nil {
+			return nil, fmt.Errorf("%w: span %d %q: %w", ErrInvalidStdoutSpan, n, s.Name, err)
+		}
+		if _, ok := byTrace[traceID]; !ok {
+			order = append(order, traceID)
+		}
+		byTrace[traceID] = append(byTrace[traceID], span)
+	}
+
+	trees := make([]TraceTree, 0, len(order))
+	for _, traceID := range order {
+		trees = append(trees, TraceTree{TraceID: traceID, Roots: linkSpans(byTrace[traceID])})
+	}
+	sort.SliceStable(trees, func(i, j int) bool {
+		return trees[i].start().Before(trees[j].start())
+	})
+	return trees, nil
+}
+
+func (s stdoutSpan) treeSpan() (trace.TraceID, *TraceTreeSpan, error) {
+	traceID, err := trace.TraceIDFromHex(s.SpanContext.TraceID)
+	if err != nil {
+		return traceID, nil, fmt.Errorf("trace ID: %w", err)
+	}
+	spanID, err := trace.SpanIDFromHex(s.SpanContext.SpanID)
+	if err != nil {
+		return traceID, nil, fmt.Errorf("span ID: %w", err)
+	}
+
+	// The stdout exporter writes an all zero parent span ID for root spans,
+	// which SpanIDFromHex rejects.
+	var parentID trace.SpanID
+	if strings.Trim(s.Parent.SpanID, "0") != "" {
+		if parentID, err = trace.SpanIDFromHex(s.Parent.SpanID); err != nil {
+			return traceID, nil, fmt.Errorf("parent span ID: %w", err)
+		}
+	}
+
+	return traceID, &TraceTreeSpan{
+		Name:              s.Name,
+		SpanID:            spanID,
+		ParentSpanID:      parentID,
+		SpanKind:          s.SpanKind,
+		StartTime:         s.StartTime,
+		EndTime:           s.EndTime,
+		StatusCode:        s.Status.Code,
+		StatusDescription: s.Status.Description,
+	}, nil
+}
+
+// linkSpans attaches the spans of a trace to their parents and returns the
+// spans left without one.
+func linkSpans(spans []*TraceTreeSpan) []*TraceTreeSpan {
+	byID := make(map[trace.SpanID]*TraceTreeSpan, len(spans))
+	for _, s := range spans {
+		byID[s.SpanID] = s
+	}
+
+	var roots, orphans []*TraceTreeSpan
+	for _, s := range spans {
+		switch parent, ok := byID[s.ParentSpanID]; {
+		case !s.ParentSpanID.IsValid():
+			roots = append(roots, s)
+		case ok && parent != s:
+			parent.Children = append(parent.Children, s)
+		default:
+			orphans = append(orphans, s)
+		}
+	}
+
+	for _, s := range spans {
+		sortByStart(s.Children)
+	}
+	sortByStart(roots)
+	sortByStart(orphans)
+	return breakCycles(spans, byID, append(roots, orphans...))
+}
+
+// breakCycles returns roots followed by the spans promoted to roots so that
+// every span is reachable from one: spans whose parents form a cycle, which
+// can only come from corrupted input, are otherwise never reached. The
+// earliest span of each cycle is detached from its parent and promoted.
+func breakCycles(spans []*TraceTreeSpan, byID map[trace.SpanID]*TraceTreeSpan, roots []*TraceTreeSpan) []*TraceTreeSpan {
+	reached := make(map[*TraceTreeSpan]bool, len(spans))
+	var mark func(*TraceTreeSpan)
+	mark = func(s *TraceTreeSpan) {
+		reached[s] = true
+		for _, c := range s.Children {
+			mark(c)
+		}
+	}
+	for _, r := range roots {
+		mark(r)
+	}
+
+	sorted := slices.Clone(spans)
+	sortByStart(sorted)
+	for _, s := range sorted {
+		if reached[s] {
+			continue
+		}
+		// The parents of an unreached span all exist and lead to a cycle.
+		seen := make(map[*TraceTreeSpan]bool)
+		for !seen[s] {
+			seen[s] = true
+			s = byID[s.ParentSpanID]
+		}
+		first := s
+		for p := byID[s.ParentSpanID]; p != s; p = byID[p.ParentSpanID] {
+			if p.StartTime.Before(first.StartTime) {
+				first = p
+			}
+		}
+
+		parent := byID[first.ParentSpanID]
+		parent.Children = slices.DeleteFunc(parent.Children, func(c *TraceTreeSpan) bool { return c == first })
+		first.cyclic = true
+		roots = append(roots, first)
+		mark(first)
+	}
+	return roots
+}
+
+// start returns the start time of the first root of the trace.
+func (t TraceTree) start() time.Time {
+	if len(t.Roots) == 0 {
+		return time.Time{}
+	}
+	return t.Roots[0].StartTime
+}
+
+func sortByStart(spans []*TraceTreeSpan) {
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].StartTime.Before(spans[j].StartTime) })
+}
+
+// Render writes the trace as an indented tree, one span per line with its
+// duration and status. Spans whose parent is missing from the trace, or
+// whose parents form a cycle, are marked with the parent span ID.
+func (t TraceTree) Render(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "trace %s\n", t.TraceID); err != nil {
+		return err
+	}
+	for i, s := range t.Roots {
+		if err := renderSpan(w, s, "", i == len(t.Roots)-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderSpan(w io.Writer, s *TraceTreeSpan, indent string, last bool) error {
+	branch, next := "├── ", "│   "
+	if last {
+		branch, next = "└── ", "    "
+	}
+
+	line := fmt.Sprintf("%s%s%s (%s) %s", indent, branch, s.Name, formatSpanDuration(s), s.StatusCode)
+	if s.StatusDescription != "" {
+		line += ": " + s.StatusDescription
+	}
+	switch {
+	case s.cyclic:
+		line += fmt.Sprintf(" [parent %s forms a cycle]", s.ParentSpanID)
+	case s.ParentSpanID.IsValid() && indent == "":
+		line += fmt.Sprintf(" [parent %s not found]", s.ParentSpanID)
+	}
+	if _, err := fmt.Fprintln(w, line); err != nil {
+		return err
+	}
+
+	for i, c := range s.Children {
+		if err := renderSpan(w, c, indent+next, i == len(s.Children)-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatSpanDuration(s *TraceTreeSpan) string {
+	if s.EndTime.IsZero() {
+		return "not ended"
+	}
+	d := s.Duration()
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	}
+	return d.String()
+}
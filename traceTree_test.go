@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// writeStdoutTraces records two traces with the stdout exporter: a request
+// with two children, one of them failing, and a span continuing a remote
+// parent.
+func writeStdoutTraces(t *testing.T, opts ...stdouttrace.Option) []byte {
+	var buf bytes.Buffer
+	exporter, err := stdouttrace.New(append(opts, stdouttrace.WithWriter(&buf))...)
+	require.NoError(t, err)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := tp.Tracer("test")
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	ctx, root := tracer.Start(context.Background(), "GET /devices", trace.WithTimestamp(at(0)), trace.WithSpanKind(trace.SpanKindServer))
+	_, second := tracer.Start(ctx, "publish", trace.WithTimestamp(at(3*time.Millisecond)))
+	second.SetStatus(codes.Error, "broker unavailable")
+	second.End(trace.WithTimestamp(at(5 * time.Millisecond)))
+	_, first := tracer.Start(ctx, "lookup", trace.WithTimestamp(at(time.Millisecond)))
+	first.End(trace.WithTimestamp(at(2 * time.Millisecond)))
+	root.End(trace.WithTimestamp(at(1500 * time.Millisecond)))
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9},
+		SpanID:     trace.SpanID{0x00, 0xf0},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	_, orphan := tracer.Start(trace.ContextWithRemoteSpanContext(context.Background(), parent), "consume", trace.WithTimestamp(at(-time.Second)))
+	orphan.End(trace.WithTimestamp(at(-time.Second + 250*time.Microsecond)))
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+	return buf.Bytes()
+}
+
+func TestReadTraceTrees(t *testing.T) {
+	for _, tc := range []struct {
+		Description string
+		Options     []stdouttrace.Option
+	}{
+		{Description: "Compact"},
+		{Description: "Pretty printed", Options: []stdouttrace.Option{stdouttrace.WithPrettyPrint()}},
+	} {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			trees, err := ReadTraceTrees(bytes.NewReader(writeStdoutTraces(t, tc.Options...)))
+			require.NoError(err)
+			require.Len(trees, 2)
+
+			assert.Equal("4bf90000000000000000000000000000", trees[0].TraceID.String())
+			require.Len(trees[0].Roots, 1)
+			assert.Equal("consume", trees[0].Roots[0].Name)
+			assert.Equal("00f0000000000000", trees[0].Roots[0].ParentSpanID.String())
+
+			require.Len(trees[1].Roots, 1)
+			root := trees[1].Roots[0]
+			assert.Equal("GET /devices", root.Name)
+			assert.Equal(trace.SpanKindServer, root.SpanKind)
+			assert.Equal(1500*time.Millisecond, root.Duration())
+			require.Len(root.Children, 2)
+			assert.Equal("lookup", root.Children[0].Name)
+			assert.Equal("publish", root.Children[1].Name)
+			assert.Equal(codes.Error, root.Children[1].StatusCode)
+			assert.Equal("broker unavailable", root.Children[1].StatusDescription)
+		})
+	}
+}
+
+func TestReadTraceTreesErrors(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Input       string
+		Expected    string
+	}{
+		{Description: "Not JSON", Input: "hello", Expected: "span 1"},
+		{Description: "Truncated", Input: `{"Name": "a", "SpanContext": {"TraceID": "4bf92f3577b34da6a3ce929d0e0e4736", "SpanID": "00f067aa0ba902b7"}} {"Name": `, Expected: "span 2"},
+		{Description: "Bad trace ID", Input: `{"Name": "a", "SpanContext": {"TraceID": "xyz", "SpanID": "00f067aa0ba902b7"}}`, Expected: `span 1 "a": trace ID`},
+		{Description: "Bad parent", Input: `{"Name": "a", "SpanContext": {"TraceID": "4bf92f3577b34da6a3ce929d0e0e4736", "SpanID": "00f067aa0ba902b7"}, "Parent": {"SpanID": "12"}}`, Expected: "parent span ID"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			_, err := ReadTraceTrees(strings.NewReader(tc.Input))
+			assert.ErrorIs(t, err, ErrInvalidStdoutSpan)
+			assert.ErrorContains(t, err, tc.Expected)
+		})
+	}
+}
+
+func TestTraceTreeRender(t *testing.T) {
+	trees, err := ReadTraceTrees(bytes.NewReader(writeStdoutTraces(t)))
+	require.NoError(t, err)
+	require.Len(t, trees, 2)
+
+	var buf bytes.Buffer
+	for _, tree := range trees {
+		require.NoError(t, tree.Render(&buf))
+	}
+	expected := `trace 4bf90000000000000000000000000000
+└── consume (250µs) Unset [parent 00f0000000000000 not found]
+trace ` + trees[1].TraceID.String() + `
+└── GET /devices (1.5s) Unset
+    ├── lookup (1ms) Unset
+    └── publish (2ms) Error: broker unavailable
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+// cyclicSpan is a span of trace 4bf92f3577b34da6a3ce929d0e0e4736 started at
+// the given second.
+func cyclicSpan(name, spanID, parentID string, second int) string {
+	start := time.Date(2026, 1, 2, 3, 4, second, 0, time.UTC)
+	return fmt.Sprintf(`{"Name": %q, "SpanContext": {"TraceID": "4bf92f3577b34da6a3ce929d0e0e4736", "SpanID": %q},
+		"Parent": {"SpanID": %q}, "StartTime": %q, "EndTime": %q}`,
+		name, spanID, parentID, start.Format(time.RFC3339), start.Add(time.Second).Format(time.RFC3339))
+}
+
+func TestReadTraceTreesCycle(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Spans       []string
+		Expected    string
+	}{
+		{
+			Description: "Only a cycle",
+			Spans: []string{
+				cyclicSpan("b", "000000000000000b", "000000000000000a", 2),
+				cyclicSpan("a", "000000000000000a", "000000000000000b", 1),
+				cyclicSpan("c", "000000000000000c", "000000000000000b", 3),
+			},
+			Expected: `trace 4bf92f3577b34da6a3ce929d0e0e4736
+└── a (1s) Unset [parent 000000000000000b forms a cycle]
+    └── b (1s) Unset
+        └── c (1s) Unset
+`,
+		},
+		{
+			Description: "Cycle beside a root",
+			Spans: []string{
+				cyclicSpan("root", "0000000000000001", "0000000000000000", 5),
+				cyclicSpan("child", "0000000000000002", "0000000000000001", 6),
+				cyclicSpan("a", "000000000000000a", "000000000000000b", 1),
+				cyclicSpan("b", "000000000000000b", "000000000000000a", 2),
+			},
+			Expected: `trace 4bf92f3577b34da6a3ce929d0e0e4736
+├── root (1s) Unset
+│   └── child (1s) Unset
+└── a (1s) Unset [parent 000000000000000b forms a cycle]
+    └── b (1s) Unset
+`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			trees, err := ReadTraceTrees(strings.NewReader(strings.Join(tc.Spans, "\n")))
+			require.NoError(t, err)
+			require.Len(t, trees, 1)
+
+			var buf bytes.Buffer
+			require.NoError(t, trees[0].Render(&buf))
+			assert.Equal(t, tc.Expected, buf.String())
+		})
+	}
+}
@@ -50,8 +50,8 @@ func ExtractTraceInfo(ctx context.Context) (string, string, bool) {
 }
 
 // InjectTraceInfo will be injecting traceParent and tracestate as
-// headers in carrier from span which is available in context.
+// headers in carrier from span which is available in context, along with
+// the baggage of the context.
 func InjectTraceInfo(ctx context.Context, carrier propagation.TextMapCarrier) {
-	prop := propagation.TraceContext{}
-	prop.Inject(ctx, carrier)
+	defaultPropagator().Inject(ctx, carrier)
 }
@@ -40,22 +40,85 @@ const DefaultTracerProvider = "noop"
 // A different provider can be used if a constructor for it is provided in the
 // config.
 // If a provider name is not provided, a noop tracerProvider will be returned.
+// The config is first checked with Config.Validate, so that a config passing
+// Validate is one this function accepts and the other way around.
 func ConfigureTracerProvider(config Config) (trace.TracerProvider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+	}
 	if len(config.Provider) == 0 {
 		config.Provider = DefaultTracerProvider
 	}
 	// Handling camelcase of provider.
 	config.Provider = strings.ToLower(config.Provider)
+	if config.ErrorHandler != nil && config.options.reporter == nil {
+		config.options.reporter = newErrorReporter(config)
+	}
 	providerConfig := config.Providers[config.Provider]
-	parentBasedTracing := config.ParentBased
-	noParentTracing := config.NoParent
 	if providerConfig == nil {
 		providerConfig = providersConfig[config.Provider]
 	}
+	if config.options.exporter != nil {
+		providerConfig = exporterProvider
+	}
 	if providerConfig == nil {
 		return nil, fmt.Errorf("%w for provider %s", ErrTracerProviderNotFound, config.Provider)
 	}
 
+	sampler, err := configSampler(config)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := providerConfig(config, sampler)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+	}
+	return provider, nil
+}
+
+// providerSamplers are the samplers the jaeger, zipkin and stdout providers
+// used before they honored ParentBased and NoParent. They are kept when both
+// settings are unset, so that configs relying on them still export spans.
+var providerSamplers = map[string]sdktrace.Sampler{
+	"jaeger": sdktrace.AlwaysSample(),
+	"zipkin": sdktrace.AlwaysSample(),
+	"stdout": sdktrace.ParentBased(sdktrace.AlwaysSample()),
+}
+
+// configSampler builds the sampler described by the ParentBased, NoParent
+// and DeviceSampling settings of config, or given with WithSampler. Unless
+// ParentBased or NoParent is set, the jaeger, zipkin and stdout providers
+// start from the sampler in providerSamplers instead.
+func configSampler(config Config) (sdktrace.Sampler, error) {
+	sampler, err := headSampler(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.options.sampler != nil {
+		sampler = config.options.sampler
+	}
+
+	if config.DeviceSampling.enabled() {
+		return NewDeviceSampler(config.DeviceSampling, sampler)
+	}
+	return sampler, nil
+}
+
+// headSampler returns the sampler selected by the ParentBased and NoParent
+// settings of config.
+func headSampler(config Config) (sdktrace.Sampler, error) {
+	_, custom := config.Providers[config.Provider]
+	if config.ParentBased == "" && config.NoParent == "" && !custom && config.options.exporter == nil {
+		if sampler, ok := providerSamplers[config.Provider]; ok {
+			return sampler, nil
+		}
+	}
+
+	parentBasedTracing := config.ParentBased
+	noParentTracing := config.NoParent
+
 	// If parentBased value is empty, use default value
 	if parentBasedTracing == "" {
 		// nolint:goconst
@@ -89,12 +152,115 @@ func ConfigureTracerProvider(config Config) (trace.TracerProvider, error) {
 	default:
 		return nil, ErrInvalidParentBasedValue
 	}
+	return sampler, nil
+}
 
-	provider, err := providerConfig(config, sampler)
+// exportSpanProcessor builds the span processor through which the built-in
+// providers hand spans to exporter, applying the span processing settings of
+// cfg. Spans are exported synchronously if syncer is true and in batches
+// otherwise.
+func exportSpanProcessor(cfg Config, exporter sdktrace.SpanExporter, syncer bool) (sdktrace.SpanProcessor, error) {
+	redactor, err := newRedactor(cfg.Redactions)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrTracerProviderBuildFailed, err)
+		_ = exporter.Shutdown(context.Background())
+		return nil, err
 	}
-	return provider, nil
+	if cfg.Retry.Enabled {
+		retrying, err := NewRetryExporter(exporter, cfg.Retry)
+		if err != nil {
+			_ = exporter.Shutdown(context.Background())
+			return nil, err
+		}
+		exporter = retrying
+	}
+	if cfg.options.reporter != nil {
+		exporter = &reportingExporter{next: exporter, reporter: cfg.options.reporter}
+	}
+
+	var processor sdktrace.SpanProcessor
+	if syncer {
+		processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	} else {
+		processor = sdktrace.NewBatchSpanProcessor(exporter)
+	}
+	if redactor != nil {
+		processor = &redactionProcessor{redactor: redactor, next: processor}
+	}
+	if cfg.TailSampling.Enabled {
+		// Tail sampling sees spans before redaction, so that its policies
+		// can match attributes that are not exported.
+		sampling, err := NewTailSamplingProcessor(cfg.TailSampling, processor)
+		if err != nil {
+			_ = processor.Shutdown(context.Background())
+			return nil, err
+		}
+		processor = sampling
+	}
+	return processor, nil
+}
+
+// validateEndpoint runs the endpoint check of Config.Validate for the
+// built-in provider, so that both accept the same endpoints.
+func validateEndpoint(provider, endpoint string) error {
+	if err := endpointValidators[provider](endpoint); err != nil {
+		return fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+	}
+	return nil
+}
+
+// otlpGRPCOptions translates cfg into the options of the otlp/grpc exporter.
+func otlpGRPCOptions(cfg Config) ([]otlptracegrpc.Option, error) {
+	options := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+		otlptracegrpc.WithInsecure(),
+	}
+	if strings.Contains(cfg.Endpoint, "://") {
+		options = []otlptracegrpc.Option{otlptracegrpc.WithEndpointURL(cfg.Endpoint)}
+	}
+	switch cfg.Compression {
+	case "", CompressionNone:
+	case CompressionGzip:
+		options = append(options, otlptracegrpc.WithCompressor(CompressionGzip))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCompression, cfg.Compression)
+	}
+	if cfg.Timeout > 0 {
+		options = append(options, otlptracegrpc.WithTimeout(cfg.Timeout))
+	}
+	if cfg.Retry.Enabled {
+		// Retries are made by the retry exporter wrapping this one.
+		options = append(options, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}))
+	}
+	return options, nil
+}
+
+// otlpHTTPOptions translates cfg into the options of the otlp/http exporter.
+func otlpHTTPOptions(cfg Config) ([]otlptracehttp.Option, error) {
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.Endpoint),
+		otlptracehttp.WithInsecure(),
+	}
+	if strings.Contains(cfg.Endpoint, "://") {
+		options = []otlptracehttp.Option{otlptracehttp.WithEndpointURL(cfg.Endpoint)}
+	}
+	switch cfg.Compression {
+	case "", CompressionNone:
+	case CompressionGzip:
+		options = append(options, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCompression, cfg.Compression)
+	}
+	if cfg.Timeout > 0 {
+		options = append(options, otlptracehttp.WithTimeout(cfg.Timeout))
+	}
+	if cfg.Retry.Enabled {
+		// Retries are made by the retry exporter wrapping this one.
+		options = append(options, otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}))
+	}
+	if cfg.URLPath != "" {
+		options = append(options, otlptracehttp.WithURLPath(cfg.URLPath))
+	}
+	return options, nil
 }
 
 // ProviderConstructor is useful when client wants to add their own custom
@@ -106,61 +272,67 @@ var providersConfig = map[string]ProviderConstructor{
 	// nolint:goconst
 	"otlp/grpc": func(cfg Config, smplr sdktrace.Sampler) (trace.TracerProvider, error) {
 		// Send traces over gRPC
-		if cfg.Endpoint == "" {
-			return nil, ErrTracerProviderBuildFailed
+		if err := validateEndpoint("otlp/grpc", cfg.Endpoint); err != nil {
+			return nil, err
 		}
-		exporter, err := otlptracegrpc.New(context.Background(),
-
-			otlptracegrpc.WithEndpoint(cfg.Endpoint),
-			otlptracegrpc.WithInsecure(),
-		)
+		options, err := otlpGRPCOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err := otlptracegrpc.New(context.Background(), options...)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %v", ErrTracerProviderBuildFailed, err)
 		}
+		processor, err := exportSpanProcessor(cfg, exporter, false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+		}
 
-		return sdktrace.NewTracerProvider(
-			sdktrace.WithBatcher(exporter),
-			sdktrace.WithResource(
-				resource.NewWithAttributes(
-					semconv.SchemaURL,
-					semconv.ServiceNameKey.String(cfg.ApplicationName),
-				),
+		return newSDKTracerProvider(cfg,
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceNameKey.String(cfg.ApplicationName),
 			),
+			sdktrace.WithSpanProcessor(processor),
+			sdktrace.WithRawSpanLimits(cfg.SpanLimits.sdkSpanLimits()),
 			sdktrace.WithSampler(smplr),
-		), nil
+		)
 
 	},
 	// nolint:goconst
 	"otlp/http": func(cfg Config, smplr sdktrace.Sampler) (trace.TracerProvider, error) {
 		// Send traces over HTTP
-		if cfg.Endpoint == "" {
-			return nil, ErrTracerProviderBuildFailed
+		if err := validateEndpoint("otlp/http", cfg.Endpoint); err != nil {
+			return nil, err
 		}
-		exporter, err := otlptracehttp.New(context.Background(),
-
-			otlptracehttp.WithEndpoint(cfg.Endpoint),
-			otlptracehttp.WithInsecure(),
-		)
+		options, err := otlpHTTPOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err := otlptracehttp.New(context.Background(), options...)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %v", ErrTracerProviderBuildFailed, err)
 		}
+		processor, err := exportSpanProcessor(cfg, exporter, false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+		}
 
-		return sdktrace.NewTracerProvider(
-			sdktrace.WithBatcher(exporter),
-			sdktrace.WithResource(
-				resource.NewWithAttributes(
-					semconv.SchemaURL,
-					semconv.ServiceNameKey.String(cfg.ApplicationName),
-				),
+		return newSDKTracerProvider(cfg,
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceNameKey.String(cfg.ApplicationName),
 			),
+			sdktrace.WithSpanProcessor(processor),
+			sdktrace.WithRawSpanLimits(cfg.SpanLimits.sdkSpanLimits()),
 			sdktrace.WithSampler(smplr),
-		), nil
+		)
 
 	},
 	// nolint:goconst
 	"jaeger": func(cfg Config, smplr sdktrace.Sampler) (trace.TracerProvider, error) {
-		if cfg.Endpoint == "" {
-			return nil, ErrTracerProviderBuildFailed
+		if err := validateEndpoint("jaeger", cfg.Endpoint); err != nil {
+			return nil, err
 		}
 
 		exporter, err := jaeger.New(
@@ -169,40 +341,46 @@ var providersConfig = map[string]ProviderConstructor{
 		if err != nil {
 			return nil, fmt.Errorf("%w: %v", ErrTracerProviderBuildFailed, err)
 		}
+		processor, err := exportSpanProcessor(cfg, exporter, false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+		}
 
-		tp := sdktrace.NewTracerProvider(
-			sdktrace.WithBatcher(exporter),
-			sdktrace.WithSampler(sdktrace.AlwaysSample()),
-			sdktrace.WithResource(
-				resource.NewWithAttributes(
-					semconv.SchemaURL,
-					semconv.ServiceNameKey.String(cfg.ApplicationName),
-					attribute.String("exporter", cfg.Provider),
-				)),
+		return newSDKTracerProvider(cfg,
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceNameKey.String(cfg.ApplicationName),
+				attribute.String("exporter", cfg.Provider),
+			),
+			sdktrace.WithSpanProcessor(processor),
+			sdktrace.WithRawSpanLimits(cfg.SpanLimits.sdkSpanLimits()),
+			sdktrace.WithSampler(smplr),
 		)
-		return tp, nil
 	},
 	"zipkin": func(cfg Config, smplr sdktrace.Sampler) (trace.TracerProvider, error) {
-		if cfg.Endpoint == "" {
-			return nil, ErrTracerProviderBuildFailed
+		if err := validateEndpoint("zipkin", cfg.Endpoint); err != nil {
+			return nil, err
 		}
 
 		exporter, err := zipkin.New(cfg.Endpoint)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %v", ErrTracerProviderBuildFailed, err)
 		}
+		processor, err := exportSpanProcessor(cfg, exporter, false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+		}
 
-		tp := sdktrace.NewTracerProvider(
-			sdktrace.WithBatcher(exporter),
-			sdktrace.WithSampler(sdktrace.AlwaysSample()),
-			sdktrace.WithResource(
-				resource.NewWithAttributes(
-					semconv.SchemaURL,
-					semconv.ServiceNameKey.String(cfg.ApplicationName),
-					attribute.String("exporter", cfg.Provider),
-				)),
+		return newSDKTracerProvider(cfg,
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceNameKey.String(cfg.ApplicationName),
+				attribute.String("exporter", cfg.Provider),
+			),
+			sdktrace.WithSpanProcessor(processor),
+			sdktrace.WithRawSpanLimits(cfg.SpanLimits.sdkSpanLimits()),
+			sdktrace.WithSampler(smplr),
 		)
-		return tp, nil
 	},
 	// nolint:goconst
 	"stdout": func(cfg Config, smplr sdktrace.Sampler) (trace.TracerProvider, error) {
@@ -216,8 +394,15 @@ var providersConfig = map[string]ProviderConstructor{
 		if err != nil {
 			return nil, err
 		}
-		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
-		return tp, nil
+		processor, err := exportSpanProcessor(cfg, exporter, true)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTracerProviderBuildFailed, err)
+		}
+		return newSDKTracerProvider(cfg, nil,
+			sdktrace.WithSpanProcessor(processor),
+			sdktrace.WithRawSpanLimits(cfg.SpanLimits.sdkSpanLimits()),
+			sdktrace.WithSampler(smplr),
+		)
 	},
 	"noop": func(config Config, smplr sdktrace.Sampler) (trace.TracerProvider, error) {
 		return noop.NewTracerProvider(), nil
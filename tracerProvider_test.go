@@ -4,11 +4,21 @@
 package candlelight
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/candlelight/collectortest"
+	"go.opentelemetry.io/otel/attribute"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 )
@@ -137,6 +147,76 @@ func TestConfigureTracerProvider(t *testing.T) {
 			},
 			Err: ErrTracerProviderBuildFailed,
 		},
+		{
+			Description: "Otlp/HTTP: Invalid endpoint",
+			Config: Config{
+				Provider: "otlp/http",
+				Endpoint: "localhost:4318/v1/traces",
+			},
+			Err: ErrInvalidEndpoint,
+		},
+		{
+			Description: "Zipkin: Invalid endpoint",
+			Config: Config{
+				Provider: "zipkin",
+				Endpoint: "localhost:9411",
+			},
+			Err: ErrInvalidEndpoint,
+		},
+		{
+			Description: "Otlp/HTTP: Valid compression and timeout",
+			Config: Config{
+				Provider:    "otlp/http",
+				Endpoint:    "localhost:4318",
+				Compression: "gzip",
+				Timeout:     time.Second,
+				URLPath:     "/custom/v1/traces",
+			},
+		},
+		{
+			Description: "Otlp/gRPC: Valid compression and timeout",
+			Config: Config{
+				Provider:    "otlp/grpc",
+				Endpoint:    "localhost:4317",
+				Compression: "gzip",
+				Timeout:     time.Second,
+			},
+		},
+		{
+			Description: "Otlp/gRPC: Invalid compression",
+			Config: Config{
+				Provider:    "otlp/grpc",
+				Endpoint:    "localhost:4317",
+				Compression: "zstd",
+			},
+			Err: ErrInvalidCompression,
+		},
+		{
+			Description: "Otlp/HTTP: Invalid compression",
+			Config: Config{
+				Provider:    "otlp/http",
+				Endpoint:    "localhost:4318",
+				Compression: "zstd",
+			},
+			Err: ErrInvalidCompression,
+		},
+		{
+			Description: "Zipkin: Valid retry",
+			Config: Config{
+				Provider: "zipkin",
+				Endpoint: "http://localhost:9411/api/v2/spans",
+				Retry:    RetryConfig{Enabled: true},
+			},
+		},
+		{
+			Description: "Otlp/HTTP: Invalid retry",
+			Config: Config{
+				Provider: "otlp/http",
+				Endpoint: "localhost:4318",
+				Retry:    RetryConfig{Enabled: true, MaxElapsedTime: -time.Second},
+			},
+			Err: ErrInvalidRetryValue,
+		},
 		{
 			Description: "Jaeger: Missing endpoint",
 			Config: Config{
@@ -186,6 +266,23 @@ func TestConfigureTracerProvider(t *testing.T) {
 				SkipTraceExport: true,
 			},
 		},
+		{
+			Description: "Stdout: Valid redactions",
+			Config: Config{
+				Provider:        "stdout",
+				SkipTraceExport: true,
+				Redactions:      []RedactionRule{{Key: "wrp.*", Action: RedactHash}},
+			},
+		},
+		{
+			Description: "Otlp/HTTP: Invalid redactions",
+			Config: Config{
+				Provider:   "otlp/http",
+				Endpoint:   "localhost:4318",
+				Redactions: []RedactionRule{{Key: "wrp.*", Action: "erase"}},
+			},
+			Err: ErrInvalidRedactionAction,
+		},
 		{
 			Description: "Default",
 			Config:      Config{},
@@ -219,6 +316,266 @@ func TestConfigureTracerProvider(t *testing.T) {
 				assert.NotNil(tp)
 			}
 			assert.True(errors.Is(err, tc.Err))
+			assert.Equal(err == nil, tc.Config.Validate() == nil, "Validate agrees")
+		})
+	}
+}
+
+func TestBuiltInProvidersApplySpanLimits(t *testing.T) {
+	tcs := []Config{
+		{Provider: "otlp/grpc", Endpoint: "localhost:4317"},
+		{Provider: "otlp/http", Endpoint: "localhost:4318"},
+		{Provider: "jaeger", Endpoint: "http://localhost:14268/api/traces"},
+		{Provider: "zipkin", Endpoint: "http://localhost:9411/api/v2/spans"},
+		{Provider: "stdout", SkipTraceExport: true},
+	}
+
+	for _, config := range tcs {
+		t.Run(config.Provider, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			config.ParentBased = "honor"
+			config.NoParent = "always"
+			config.SpanLimits = SpanLimits{
+				AttributeValueLengthLimit: 8,
+				AttributeCountLimit:       2,
+				EventCountLimit:           3,
+			}
+			tp, err := ConfigureTracerProvider(config)
+			require.NoError(err)
+			sdkProvider, ok := tp.(*sdktrace.TracerProvider)
+			require.True(ok)
+			defer func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_ = sdkProvider.Shutdown(ctx)
+			}()
+
+			exporter := tracetest.NewInMemoryExporter()
+			sdkProvider.RegisterSpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter))
+
+			_, span := tp.Tracer("test").Start(context.Background(), "limited")
+			span.SetAttributes(
+				attribute.String("a", "0123456789"),
+				attribute.Int("b", 1),
+				attribute.Int("c", 2),
+			)
+			for i := 0; i < 10; i++ {
+				span.AddEvent("event")
+			}
+			span.End()
+
+			spans := exporter.GetSpans()
+			require.Len(spans, 1)
+			assert.Equal([]attribute.KeyValue{attribute.String("a", "01234567"), attribute.Int("b", 1)}, spans[0].Attributes)
+			assert.Equal(1, spans[0].DroppedAttributes)
+			assert.Len(spans[0].Events, 3)
+			assert.Equal(7, spans[0].DroppedEvents)
+		})
+	}
+}
+
+func TestOtlpProvidersExport(t *testing.T) {
+	collector, err := collectortest.New()
+	require.NoError(t, err)
+	defer collector.Close()
+
+	tcs := []struct {
+		Provider string
+		Endpoint string
+		Protocol string
+		Path     string
+	}{
+		{
+			Provider: "otlp/grpc",
+			Endpoint: collector.GRPCEndpoint,
+			Protocol: collectortest.ProtocolGRPC,
+			Path:     "/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+		},
+		{
+			Provider: "otlp/http",
+			Endpoint: collector.HTTPEndpoint,
+			Protocol: collectortest.ProtocolHTTP,
+			Path:     "/custom/v1/traces",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Provider, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			collector.Reset()
+
+			tp, err := ConfigureTracerProvider(Config{
+				ApplicationName: "candlelight-test",
+				Provider:        tc.Provider,
+				Endpoint:        tc.Endpoint,
+				ParentBased:     "honor",
+				NoParent:        "always",
+				Compression:     CompressionGzip,
+				Timeout:         5 * time.Second,
+				URLPath:         "/custom/v1/traces",
+				SpanLimits:      SpanLimits{AttributeValueLengthLimit: 4},
+				Redactions:      []RedactionRule{{Key: "password", Action: RedactDrop}},
+			})
+			require.NoError(err)
+			sdkProvider, ok := tp.(*sdktrace.TracerProvider)
+			require.True(ok)
+
+			_, span := tp.Tracer("test").Start(context.Background(), "exported")
+			span.SetAttributes(attribute.String("device", "mac:112233445566"), attribute.String("password", "secret"))
+			span.End()
+			require.NoError(sdkProvider.ForceFlush(context.Background()))
+			require.NoError(sdkProvider.Shutdown(context.Background()))
+
+			spans := collector.Spans()
+			require.Len(spans, 1)
+			assert.Equal("exported", spans[0].Name)
+			require.Len(spans[0].Attributes, 1)
+			assert.Equal("device", spans[0].Attributes[0].Key)
+			assert.Equal("mac:", spans[0].Attributes[0].Value.GetStringValue())
+			resource := spans[0].Resource.GetAttributes()
+			require.Len(resource, 1)
+			assert.Equal("service.name", resource[0].Key)
+			assert.Equal("candlelight-test", resource[0].Value.GetStringValue())
+
+			requests := collector.Requests()
+			require.Len(requests, 1)
+			assert.Equal(tc.Protocol, requests[0].Protocol)
+			assert.Equal(tc.Path, requests[0].Path)
+			assert.Equal(CompressionGzip, requests[0].Compression)
+		})
+	}
+}
+
+func TestOtlpProvidersExportFailure(t *testing.T) {
+	collector, err := collectortest.New()
+	require.NoError(t, err)
+	defer collector.Close()
+	collector.Reject(true)
+
+	for _, config := range []Config{
+		{Provider: "otlp/grpc", Endpoint: collector.GRPCEndpoint},
+		{Provider: "otlp/http", Endpoint: collector.HTTPEndpoint},
+	} {
+		t.Run(config.Provider, func(t *testing.T) {
+			config.ParentBased = "honor"
+			config.NoParent = "always"
+			tp, err := ConfigureTracerProvider(config)
+			require.NoError(t, err)
+			sdkProvider, ok := tp.(*sdktrace.TracerProvider)
+			require.True(t, ok)
+
+			_, span := tp.Tracer("test").Start(context.Background(), "rejected")
+			span.End()
+			assert.Error(t, sdkProvider.ForceFlush(context.Background()))
+			_ = sdkProvider.Shutdown(context.Background())
+			assert.Empty(t, collector.Spans())
+		})
+	}
+}
+
+func TestOtlpProvidersRetryOnce(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		// Retryable by the OTLP exporters.
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tp, err := ConfigureTracerProvider(Config{
+		Provider:    "otlp/http",
+		Endpoint:    strings.TrimPrefix(server.URL, "http://"),
+		ParentBased: "honor",
+		NoParent:    "always",
+		Retry:       RetryConfig{Enabled: true, InitialInterval: time.Millisecond, MaxElapsedTime: time.Millisecond},
+	})
+	require.NoError(t, err)
+	sdkProvider, ok := tp.(*sdktrace.TracerProvider)
+	require.True(t, ok)
+	defer func() { _ = sdkProvider.Shutdown(context.Background()) }()
+
+	_, span := tp.Tracer("test").Start(context.Background(), "unavailable")
+	span.End()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	assert.Error(t, sdkProvider.ForceFlush(ctx))
+	assert.Equal(t, int32(1), requests.Load(), "the exporter does not retry on its own")
+}
+
+func TestOtlpProvidersEndpointURL(t *testing.T) {
+	collector, err := collectortest.New()
+	require.NoError(t, err)
+	defer collector.Close()
+
+	for _, config := range []Config{
+		{Provider: "otlp/grpc", Endpoint: "http://" + collector.GRPCEndpoint},
+		{Provider: "otlp/http", Endpoint: "http://" + collector.HTTPEndpoint},
+	} {
+		t.Run(config.Provider, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			collector.Reset()
+
+			config.ParentBased = "honor"
+			config.NoParent = "always"
+			require.NoError(config.Validate())
+			tp, err := ConfigureTracerProvider(config)
+			require.NoError(err)
+			sdkProvider, ok := tp.(*sdktrace.TracerProvider)
+			require.True(ok)
+
+			_, span := tp.Tracer("test").Start(context.Background(), "exported")
+			span.End()
+			require.NoError(sdkProvider.ForceFlush(context.Background()))
+			require.NoError(sdkProvider.Shutdown(context.Background()))
+
+			spans := collector.Spans()
+			require.Len(spans, 1)
+			assert.Equal("exported", spans[0].Name)
+		})
+	}
+}
+
+func TestBuiltInProvidersDefaultSampling(t *testing.T) {
+	tcs := []struct {
+		Config  Config
+		Sampled bool
+	}{
+		{Config: Config{Provider: "otlp/grpc", Endpoint: "localhost:4317"}},
+		{Config: Config{Provider: "otlp/http", Endpoint: "localhost:4318"}},
+		{Config: Config{Provider: "jaeger", Endpoint: "http://localhost:14268/api/traces"}, Sampled: true},
+		{Config: Config{Provider: "zipkin", Endpoint: "http://localhost:9411/api/v2/spans"}, Sampled: true},
+		{Config: Config{Provider: "stdout", SkipTraceExport: true}, Sampled: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Config.Provider, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			tp, err := ConfigureTracerProvider(tc.Config)
+			require.NoError(err)
+			sdkProvider, ok := tp.(*sdktrace.TracerProvider)
+			require.True(ok)
+			defer func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_ = sdkProvider.Shutdown(ctx)
+			}()
+
+			_, span := tp.Tracer("test").Start(context.Background(), "default")
+			assert.Equal(tc.Sampled, span.SpanContext().IsSampled())
+
+			// Explicit settings apply to every provider.
+			tc.Config.ParentBased = "ignore"
+			tp, err = ConfigureTracerProvider(tc.Config)
+			require.NoError(err)
+			_, span = tp.Tracer("test").Start(context.Background(), "ignored")
+			assert.False(span.SpanContext().IsSampled())
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_ = tp.(*sdktrace.TracerProvider).Shutdown(ctx)
 		})
 	}
 }
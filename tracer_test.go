@@ -4,12 +4,17 @@
 package candlelight
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestExtractTraceInfo(t *testing.T) {
@@ -25,3 +30,49 @@ func TestInjectTraceInfo(t *testing.T) {
 	InjectTraceInfo(context.TODO(), propagation.HeaderCarrier(headers))
 	assert.Empty(t, headers)
 }
+
+func TestInjectTraceInfoRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var tracing Tracing
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx, err := tracing.WithPartnerID(trace.ContextWithSpanContext(context.Background(), sc), "comcast")
+	require.NoError(err)
+
+	// The outbound helper fills the trace headers of a WRP message, which the
+	// next hop reads back with EchoFirstTraceNodeInfo.
+	carrier := propagation.MapCarrier{}
+	InjectTraceInfo(ctx, carrier)
+	var headers []string
+	for _, key := range carrier.Keys() {
+		headers = append(headers, key+": "+carrier.Get(key))
+	}
+	body, err := json.Marshal(map[string]any{
+		"msg_type": 3,
+		"source":   "dns:talaria",
+		"dest":     "mac:112233445566",
+		"headers":  headers,
+	})
+	require.NoError(err)
+
+	var (
+		received trace.SpanContext
+		partner  string
+	)
+	handler := EchoFirstTraceNodeInfo(tracing, true)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = trace.SpanContextFromContext(r.Context())
+		partner = tracing.PartnerID(r.Context())
+	}))
+	r := httptest.NewRequest(http.MethodPost, "/api/v2/device", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equal(sc.TraceID(), received.TraceID())
+	assert.Equal(sc.SpanID(), received.SpanID())
+	assert.Equal("comcast", partner)
+}
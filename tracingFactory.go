@@ -4,32 +4,96 @@
 package candlelight
 
 import (
+	"context"
+
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 )
 
 // New creates a structure with components that apps can use to initialize OpenTelemetry
 // tracing instrumentation code.
-func New(config Config) (Tracing, error) {
+// Span processors requested by the config, such as span metrics, are only
+// registered when the configured provider is an OpenTelemetry SDK TracerProvider.
+// The components are also installed as the OpenTelemetry globals when
+// config.RegisterGlobal is set, see SetGlobal. The error handler is installed
+// globally whenever config.ErrorHandler is set, as the OpenTelemetry SDK
+// passes the errors of span processors and exporters to the global error
+// handler only. Tracing.RestoreGlobal puts the previous globals back. Settings that cannot be held in a Config, such as an
+// existing exporter, are given as opts.
+func New(config Config, opts ...Option) (Tracing, error) {
+	for _, o := range opts {
+		o(&config.options)
+	}
+	if config.options.errorHandler != nil {
+		config.ErrorHandler = config.options.errorHandler
+	}
 	var tracing = Tracing{
-		propagator:   propagation.TraceContext{},
+		propagator:   defaultPropagator(),
 		headerPrefix: config.HeaderPrefix,
 	}
+	if config.ErrorHandler != nil {
+		// Shared with the exporters so that the limit applies to all errors.
+		config.options.reporter = newErrorReporter(config)
+		tracing.errorHandler = config.options.reporter
+	}
+	if config.options.propagator != nil {
+		tracing.propagator = config.options.propagator
+	}
 	tracerProvider, err := ConfigureTracerProvider(config)
 	if err != nil {
 		return Tracing{}, err
 	}
+	if sdkProvider, ok := tracerProvider.(*sdktrace.TracerProvider); ok {
+		if err := registerSpanProcessors(sdkProvider, config); err != nil {
+			_ = sdkProvider.Shutdown(context.Background())
+			return Tracing{}, err
+		}
+	}
 	tracing.tracerProvider = tracerProvider
+	switch {
+	case config.RegisterGlobal:
+		tracing.globals = &globalState{restore: SetGlobal(tracing)}
+	case tracing.errorHandler != nil:
+		tracing.globals = &globalState{restore: setGlobalErrorHandler(tracing.errorHandler)}
+	}
 	return tracing, nil
 }
 
+// registerSpanProcessors adds the span processors enabled in config to the
+// tracer provider.
+func registerSpanProcessors(tp *sdktrace.TracerProvider, config Config) error {
+	if config.MeterProvider != nil {
+		processor, err := NewSpanMetricsProcessor(config.MeterProvider)
+		if err != nil {
+			return err
+		}
+		tp.RegisterSpanProcessor(processor)
+	}
+	if len(config.BaggageAttributes) > 0 {
+		tp.RegisterSpanProcessor(NewBaggageSpanProcessor(config.BaggageAttributes...))
+	}
+	for _, processor := range config.options.processors {
+		tp.RegisterSpanProcessor(processor)
+	}
+	return nil
+}
+
+// defaultPropagator propagates W3C Trace Context and Baggage.
+func defaultPropagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
 // Tracing contains the core dependencies to make tracing possible across an
 // application.
 type Tracing struct {
 	tracerProvider trace.TracerProvider
 	propagator     propagation.TextMapPropagator
 	headerPrefix   string
+	errorHandler   otel.ErrorHandler
+	globals        *globalState
 }
 
 // IsNoop returns true if the tracer provider component is a noop. False otherwise.
@@ -47,10 +111,11 @@ func (t Tracing) TracerProvider() trace.TracerProvider {
 }
 
 // Propagator returns the component that helps propagate trace context across
-// API boundaries. By default, a W3C Trace Context format propagator is returned.
+// API boundaries. By default, a propagator for the W3C Trace Context and
+// Baggage formats is returned.
 func (t Tracing) Propagator() propagation.TextMapPropagator {
 	if t.propagator == nil {
-		return propagation.TraceContext{}
+		return defaultPropagator()
 	}
 	return t.propagator
 }
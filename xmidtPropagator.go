@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// XmidtPropagator propagates span context through the X-Xmidt-Trace-ID and
+// X-Xmidt-Span-ID header pair written by TraceMiddleware, so that older
+// clients that only echo those headers can continue a trace.
+//
+// The header pair carries no trace flags or trace state, and TraceMiddleware
+// writes it whether or not the span was sampled. Extracted span contexts are
+// therefore not marked as sampled: a ParentBased sampler treats them as
+// unsampled remote parents. To let a W3C traceparent header take precedence
+// when both are present, list XmidtPropagator before propagation.TraceContext
+// in a composite propagator:
+//
+//	propagation.NewCompositeTextMapPropagator(XmidtPropagator{}, propagation.TraceContext{})
+type XmidtPropagator struct{}
+
+var _ propagation.TextMapPropagator = XmidtPropagator{}
+
+// Inject sets the trace and span ID headers from the span context in ctx.
+func (XmidtPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	carrier.Set(traceIDHeaderName, sc.TraceID().String())
+	carrier.Set(spanIDHeaderName, sc.SpanID().String())
+}
+
+// Extract returns a copy of ctx carrying the remote span context read from
+// the trace and span ID headers. ctx is returned unchanged if either header
+// is missing or invalid.
+func (XmidtPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	traceID, err := trace.TraceIDFromHex(carrier.Get(traceIDHeaderName))
+	if err != nil {
+		return ctx
+	}
+	spanID, err := trace.SpanIDFromHex(carrier.Get(spanIDHeaderName))
+	if err != nil {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Remote:  true,
+	}))
+}
+
+// Fields returns the headers used by the propagator.
+func (XmidtPropagator) Fields() []string {
+	return []string{traceIDHeaderName, spanIDHeaderName}
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package candlelight
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestXmidtPropagatorRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	headers := http.Header{}
+	XmidtPropagator{}.Inject(ctx, propagation.HeaderCarrier(headers))
+	assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", headers.Get("X-Xmidt-Trace-ID"))
+	assert.Equal("00f067aa0ba902b7", headers.Get("X-Xmidt-Span-ID"))
+
+	sc := trace.SpanContextFromContext(XmidtPropagator{}.Extract(context.Background(), propagation.HeaderCarrier(headers)))
+	assert.True(sc.IsValid())
+	assert.True(sc.IsRemote())
+	assert.False(sc.IsSampled(), "the headers carry no sampling decision")
+	assert.Equal(traceID, sc.TraceID())
+	assert.Equal(spanID, sc.SpanID())
+
+	assert.Equal([]string{"X-Xmidt-Trace-ID", "X-Xmidt-Span-ID"}, XmidtPropagator{}.Fields())
+}
+
+func TestXmidtPropagatorInvalid(t *testing.T) {
+	tcs := []struct {
+		Description string
+		Carrier     propagation.MapCarrier
+	}{
+		{Description: "Empty", Carrier: propagation.MapCarrier{}},
+		{Description: "Missing span ID", Carrier: propagation.MapCarrier{traceIDHeaderName: "4bf92f3577b34da6a3ce929d0e0e4736"}},
+		{
+			Description: "Bad trace ID",
+			Carrier:     propagation.MapCarrier{traceIDHeaderName: "not-hex", spanIDHeaderName: "00f067aa0ba902b7"},
+		},
+		{
+			Description: "Zero trace ID",
+			Carrier: propagation.MapCarrier{
+				traceIDHeaderName: "00000000000000000000000000000000",
+				spanIDHeaderName:  "00f067aa0ba902b7",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Description, func(t *testing.T) {
+			ctx := XmidtPropagator{}.Extract(context.Background(), tc.Carrier)
+			assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+
+	carrier := propagation.MapCarrier{}
+	XmidtPropagator{}.Inject(context.Background(), carrier)
+	assert.Empty(t, carrier)
+}
+
+func TestXmidtPropagatorComposite(t *testing.T) {
+	assert := assert.New(t)
+	prop := propagation.NewCompositeTextMapPropagator(XmidtPropagator{}, propagation.TraceContext{})
+
+	headers := http.Header{}
+	headers.Set(traceIDHeaderName, "11111111111111111111111111111111")
+	headers.Set(spanIDHeaderName, "2222222222222222")
+	sc := trace.SpanContextFromContext(prop.Extract(context.Background(), propagation.HeaderCarrier(headers)))
+	assert.Equal("11111111111111111111111111111111", sc.TraceID().String())
+
+	headers.Set("traceparent", testTraceParent)
+	sc = trace.SpanContextFromContext(prop.Extract(context.Background(), propagation.HeaderCarrier(headers)))
+	assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+}
+
+func TestXmidtPropagatorContinuesTraceMiddlewareTrace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tc := TraceConfig{TraceProvider: newTestTracing(exporter).TracerProvider()}
+	handler := tc.TraceMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// An older client echoes the response headers on its next request.
+	sc := trace.SpanContextFromContext(XmidtPropagator{}.Extract(context.Background(), propagation.HeaderCarrier(rec.Header())))
+	spans := exporter.GetSpans()
+	require.Len(spans, 1)
+	assert.Equal(spans[0].SpanContext.TraceID(), sc.TraceID())
+	assert.Equal(spans[0].SpanContext.SpanID(), sc.SpanID())
+}
+
+func TestXmidtPropagatorUnsampledTraceMiddlewareTrace(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.NeverSample())),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	tc := TraceConfig{TraceProvider: tp}
+	handler := tc.TraceMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(recorder.Ended())
+	assert.NotEmpty(rec.Header().Get(traceIDHeaderName), "headers are written for unsampled spans too")
+
+	ctx := XmidtPropagator{}.Extract(context.Background(), propagation.HeaderCarrier(rec.Header()))
+	assert.False(trace.SpanContextFromContext(ctx).IsSampled())
+	_, span := tp.Tracer("test").Start(ctx, "downstream")
+	span.End()
+	assert.Empty(recorder.Ended(), "downstream services follow the unsampled decision")
+}